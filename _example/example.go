@@ -8,14 +8,10 @@ import (
 
 func init() {
 	env.PanicOnRequire = true
-	var err error
-	err = env.Load("_example/example.env")
-	if err != nil {
-		// work in _example
-		err = env.Load("example.env")
-		if err != nil {
-			panic(err)
-		}
+
+	// works from the project root and from _example
+	if _, err := env.LoadNearest("_example/example.env"); err != nil {
+		panic(err)
 	}
 
 	// ensure requires
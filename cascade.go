@@ -0,0 +1,109 @@
+package env
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+)
+
+// CascadeOptions configures LoadCascade, its zero value follows the
+// dotenv-rails conventions
+type CascadeOptions struct {
+	// Dir holds the files, defaulting to the working directory
+	Dir string
+
+	// Name is the base file name, defaulting to .env
+	Name string
+
+	// Var is the key holding the environment name, defaulting to APP_ENV
+	Var string
+
+	// Default is the environment name used when Var isn't set, defaulting
+	// to development
+	Default string
+
+	// Test is the environment name in which .local files are skipped, so
+	// tests behave the same everywhere, defaulting to test
+	Test string
+
+	// Overload makes the files override variables which are already set,
+	// as Overload does, rather than only filling in unset ones
+	Overload bool
+}
+
+// LoadCascade loads the dotenv files for the current environment, named by
+// APP_ENV, in order of precedence:
+//
+//	.env.$APP_ENV.local
+//	.env.local
+//	.env.$APP_ENV
+//	.env
+//
+// So values in the first files win, and variables which are already set win
+// over all of them, unless opts.Overload is set. Missing files are skipped,
+// as are the .local files in the test environment, which are meant for
+// overrides on a developer's machine. The files which were loaded are
+// returned, highest precedence first.
+//
+// e.g.:
+//
+//	loaded, err := env.LoadCascade(env.CascadeOptions{Dir: "config"})
+func (e *Env) LoadCascade(opts CascadeOptions) ([]string, error) {
+	opts = opts.withDefaults()
+
+	name, ok := e.Lookup(opts.Var)
+	if !ok || name == "" {
+		name = opts.Default
+	}
+
+	base := filepath.Join(opts.Dir, opts.Name)
+
+	candidates := make([]string, 0, 4)
+	if name != opts.Test {
+		candidates = append(candidates, base+"."+name+".local", base+".local")
+	}
+	candidates = append(candidates, base+"."+name, base)
+
+	loaded := make([]string, 0, len(candidates))
+	for i := range candidates {
+		// overloading applies files from lowest to highest precedence
+		filename := candidates[i]
+		if opts.Overload {
+			filename = candidates[len(candidates)-1-i]
+		}
+
+		err := e.loadFile(filename, opts.Overload)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return loaded, err
+		}
+
+		// keep loaded in order of precedence, highest first
+		if opts.Overload {
+			loaded = append([]string{filename}, loaded...)
+		} else {
+			loaded = append(loaded, filename)
+		}
+	}
+
+	return loaded, nil
+}
+
+func (opts CascadeOptions) withDefaults() CascadeOptions {
+	if opts.Name == "" {
+		opts.Name = ".env"
+	}
+	if opts.Var == "" {
+		opts.Var = "APP_ENV"
+	}
+	if opts.Default == "" {
+		opts.Default = "development"
+	}
+	if opts.Test == "" {
+		opts.Test = "test"
+	}
+
+	return opts
+}
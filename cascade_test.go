@@ -0,0 +1,112 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+// cascadeDir writes a file for each level of the cascade, setting LEVEL to
+// its name and a key unique to it
+func cascadeDir(T *testing.T, names ...string) string {
+	dir := T.TempDir()
+	for _, name := range names {
+		data := "LEVEL=" + name + "\nFROM" + filepath.Ext(name) + "=" + name + "\n"
+		Go(T).AssertNil(os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644))
+	}
+
+	return dir
+}
+
+func TestLoadCascade(T *testing.T) {
+	T.Parallel()
+
+	dir := cascadeDir(T, ".env", ".env.local", ".env.production", ".env.production.local", ".env.development")
+
+	src := MapSource{"APP_ENV": "production"}
+	loaded, err := New(src).LoadCascade(CascadeOptions{Dir: dir})
+	Go(T).AssertNil(err)
+
+	Go(T).AssertDeepEqual(loaded, []string{
+		filepath.Join(dir, ".env.production.local"),
+		filepath.Join(dir, ".env.local"),
+		filepath.Join(dir, ".env.production"),
+		filepath.Join(dir, ".env"),
+	})
+	Go(T).AssertEqual(src["LEVEL"], ".env.production.local")
+	Go(T).AssertEqual(src["FROM.production"], ".env.production")
+	Go(T).AssertEqual(src["FROM.env"], ".env")
+
+	// values which are already set win
+	src = MapSource{"APP_ENV": "production", "LEVEL": "set"}
+	_, err = New(src).LoadCascade(CascadeOptions{Dir: dir})
+	Go(T).AssertNil(err)
+	Go(T).AssertEqual(src["LEVEL"], "set")
+
+	src = MapSource{"APP_ENV": "production", "LEVEL": "set"}
+	loaded, err = New(src).LoadCascade(CascadeOptions{Dir: dir, Overload: true})
+	Go(T).AssertNil(err)
+	Go(T).AssertEqual(src["LEVEL"], ".env.production.local")
+	Go(T).AssertEqual(loaded[0], filepath.Join(dir, ".env.production.local"))
+}
+
+func TestLoadCascade_defaults(T *testing.T) {
+	T.Parallel()
+
+	dir := cascadeDir(T, ".env", ".env.development")
+
+	// missing files are skipped, and the environment defaults to development
+	src := MapSource{}
+	loaded, err := New(src).LoadCascade(CascadeOptions{Dir: dir})
+	Go(T).AssertNil(err)
+	Go(T).AssertDeepEqual(loaded, []string{filepath.Join(dir, ".env.development"), filepath.Join(dir, ".env")})
+	Go(T).AssertEqual(src["LEVEL"], ".env.development")
+
+	loaded, err = New(MapSource{}).LoadCascade(CascadeOptions{Dir: T.TempDir()})
+	Go(T).AssertNil(err)
+	Go(T).AssertLength(loaded, 0)
+}
+
+func TestLoadCascade_test(T *testing.T) {
+	T.Parallel()
+
+	dir := cascadeDir(T, "app.env", "app.env.local", "app.env.ci", "app.env.ci.local")
+
+	src := MapSource{"STAGE": "ci"}
+	loaded, err := New(src).LoadCascade(CascadeOptions{Dir: dir, Name: "app.env", Var: "STAGE", Test: "ci"})
+	Go(T).AssertNil(err)
+
+	// .local files are skipped in the test environment
+	Go(T).AssertDeepEqual(loaded, []string{filepath.Join(dir, "app.env.ci"), filepath.Join(dir, "app.env")})
+	Go(T).AssertEqual(src["LEVEL"], "app.env.ci")
+}
+
+func TestLoadCascade_malformed(T *testing.T) {
+	T.Parallel()
+
+	dir := T.TempDir()
+	Go(T).AssertNil(os.WriteFile(filepath.Join(dir, ".env"), []byte("BROKEN\n"), 0o644))
+	Go(T).AssertNil(os.WriteFile(filepath.Join(dir, ".env.local"), []byte("OK=1\n"), 0o644))
+
+	loaded, err := New(MapSource{}).LoadCascade(CascadeOptions{Dir: dir})
+	Go(T).RefuteNil(err)
+	Go(T).AssertDeepEqual(loaded, []string{filepath.Join(dir, ".env.local")})
+}
+
+func TestLoadCascade_malformedOverload(T *testing.T) {
+	T.Parallel()
+
+	dir := cascadeDir(T, ".env", ".env.development", ".env.local")
+	Go(T).AssertNil(os.WriteFile(filepath.Join(dir, ".env.development.local"), []byte("BROKEN\n"), 0o644))
+
+	// files loaded before the error are still listed highest precedence first
+	loaded, err := New(MapSource{}).LoadCascade(CascadeOptions{Dir: dir, Overload: true})
+	Go(T).RefuteNil(err)
+	Go(T).AssertDeepEqual(loaded, []string{
+		filepath.Join(dir, ".env.local"),
+		filepath.Join(dir, ".env.development"),
+		filepath.Join(dir, ".env"),
+	})
+}
@@ -0,0 +1,212 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Errors collects every error found by a Checker or RequireAll. It is
+// compatible with errors.Is and errors.As, like the result of errors.Join.
+type Errors []error
+
+// Error returns a multi-line report listing every error
+func (e Errors) Error() string {
+	var b strings.Builder
+
+	if len(e) == 1 {
+		b.WriteString("1 environment error:")
+	} else {
+		fmt.Fprintf(&b, "%d environment errors:", len(e))
+	}
+
+	for _, err := range e {
+		b.WriteString("\n  - ")
+		b.WriteString(err.Error())
+	}
+
+	return b.String()
+}
+
+// Unwrap returns the collected errors
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// RequireAll requires every key, returning an Errors listing each missing
+// key rather than stopping at the first. PanicOnRequire panics once, with
+// the collected errors.
+func (e *Env) RequireAll(keys ...string) error {
+	c := e.NewChecker()
+	for _, key := range keys {
+		c.String(key, nil)
+	}
+
+	return c.Err()
+}
+
+// Checker requires a set of keys, collecting every missing, malformed or
+// invalid value rather than stopping at the first
+//
+// e.g.:
+//
+//	var (
+//	    dburl string
+//	    port  int
+//	)
+//
+//	err := env.NewChecker().
+//	    String("DATABASE_URL", &dburl).
+//	    Int("PORT", &port, env.Min(1), env.Max(65535)).
+//	    Err()
+//
+// Each method stores the value in the passed pointer when valid, and nil
+// pointers only check the value.
+type Checker struct {
+	env  *Env
+	errs Errors
+}
+
+// NewChecker returns an empty Checker reading from e
+func (e *Env) NewChecker() *Checker {
+	return &Checker{env: e}
+}
+
+// String requires key as a string
+func (c *Checker) String(key string, val *string, rules ...Rule) *Checker {
+	if str, ok := c.check(key, "string", rules); ok && val != nil {
+		*val = str
+	}
+	return c
+}
+
+// Bytes requires key as a []byte
+func (c *Checker) Bytes(key string, val *[]byte, rules ...Rule) *Checker {
+	if str, ok := c.check(key, "bytes", rules); ok && val != nil {
+		*val = []byte(str)
+	}
+	return c
+}
+
+// Duration requires key as a time.Duration
+func (c *Checker) Duration(key string, val *time.Duration, rules ...Rule) *Checker {
+	if str, ok := c.check(key, "duration", rules); ok {
+		d, err := parseDur(c.env.name(key), str)
+		if c.add(err) && val != nil {
+			*val = d
+		}
+	}
+	return c
+}
+
+// Int requires key as an int
+func (c *Checker) Int(key string, val *int, rules ...Rule) *Checker {
+	if str, ok := c.check(key, "int", rules); ok {
+		i, err := parseInt(c.env.name(key), str)
+		if c.add(err) && val != nil {
+			*val = i
+		}
+	}
+	return c
+}
+
+// Int32 requires key as an int32
+func (c *Checker) Int32(key string, val *int32, rules ...Rule) *Checker {
+	if str, ok := c.check(key, "int32", rules); ok {
+		i, err := parseInt32(c.env.name(key), str)
+		if c.add(err) && val != nil {
+			*val = i
+		}
+	}
+	return c
+}
+
+// Int64 requires key as an int64
+func (c *Checker) Int64(key string, val *int64, rules ...Rule) *Checker {
+	if str, ok := c.check(key, "int64", rules); ok {
+		i, err := parseInt64(c.env.name(key), str)
+		if c.add(err) && val != nil {
+			*val = i
+		}
+	}
+	return c
+}
+
+// Float32 requires key as a float32
+func (c *Checker) Float32(key string, val *float32, rules ...Rule) *Checker {
+	if str, ok := c.check(key, "float32", rules); ok {
+		f, err := parseFloat32(c.env.name(key), str)
+		if c.add(err) && val != nil {
+			*val = f
+		}
+	}
+	return c
+}
+
+// Float64 requires key as a float64
+func (c *Checker) Float64(key string, val *float64, rules ...Rule) *Checker {
+	if str, ok := c.check(key, "float64", rules); ok {
+		f, err := parseFloat64(c.env.name(key), str)
+		if c.add(err) && val != nil {
+			*val = f
+		}
+	}
+	return c
+}
+
+// Bool requires key as a bool
+func (c *Checker) Bool(key string, val *bool, rules ...Rule) *Checker {
+	if str, ok := c.check(key, "bool", rules); ok {
+		b, err := parseBool(c.env.name(key), str)
+		if c.add(err) && val != nil {
+			*val = b
+		}
+	}
+	return c
+}
+
+// Value requires key and decodes it into the value pointed to by v, like
+// RequireValue
+func (c *Checker) Value(key string, v interface{}, rules ...Rule) *Checker {
+	fv, err := decodeTarget(v)
+	if !c.add(err) {
+		return c
+	}
+
+	if str, ok := c.check(key, typeName(fv.Type()), rules); ok {
+		tmp := reflect.New(fv.Type()).Elem()
+		if c.add(c.env.setField(tmp, c.env.name(key), str)) {
+			fv.Set(tmp)
+		}
+	}
+	return c
+}
+
+// Errors returns the errors collected so far
+func (c *Checker) Errors() Errors {
+	return c.errs
+}
+
+// Err returns the collected errors as an Errors, or nil when every key was
+// valid, honoring PanicOnRequire
+func (c *Checker) Err() error {
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return c.env.onError(c.errs)
+}
+
+func (c *Checker) check(key, typ string, rules []Rule) (string, bool) {
+	str, err := c.env.checkRequired(key, typ, rules)
+	return str, c.add(err)
+}
+
+// add collects err, returning true if err is nil
+func (c *Checker) add(err error) bool {
+	if err != nil {
+		c.errs = append(c.errs, err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package env
+
+import (
+	. "github.com/jmervine/env/_fixtures"
+
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+func TestRequireAll(T *testing.T) {
+	defer UnsetFixtures()
+
+	e := RequireAll("F_STRING", "F_INT")
+	Go(T).RefuteNil(e)
+	Go(T).AssertEqual(e.Error(), "2 environment errors:\n  - missing required string from F_STRING\n  - missing required string from F_INT")
+	Go(T).Assert(errors.Is(e, ErrMissing))
+
+	var me *MissingError
+	Go(T).Assert(errors.As(e, &me))
+	Go(T).AssertEqual(me.Key, "F_STRING")
+
+	SetFixtures()
+	Go(T).AssertNil(RequireAll("F_STRING", "F_INT"))
+}
+
+func TestRequireAll_panic(T *testing.T) {
+	defer UnsetFixtures()
+
+	PanicOnRequire = true
+	defer func() {
+		PanicOnRequire = false
+
+		e, ok := recover().(Errors)
+		Go(T).Assert(ok)
+		Go(T).AssertLength(e, 2)
+	}()
+
+	RequireAll("F_STRING", "F_INT")
+}
+
+func TestChecker(T *testing.T) {
+	defer UnsetFixtures()
+	SetFixtures()
+	Set("F_BLANK", "127.0.0.1")
+
+	var (
+		s   string
+		b   []byte
+		d   time.Duration
+		i   int
+		i32 int32
+		i64 int64
+		f32 float32
+		f64 float64
+		t   bool
+		ip  net.IP
+	)
+
+	e := NewChecker().
+		String("F_STRING", &s).
+		Bytes("F_BYTES", &b).
+		Duration("F_DURATION", &d).
+		Int("F_INT", &i, Min(1)).
+		Int32("F_INT32", &i32).
+		Int64("F_INT64", &i64).
+		Float32("F_FLOAT32", &f32).
+		Float64("F_FLOAT64", &f64).
+		Bool("F_BOOL", &t).
+		Value("F_BLANK", &ip).
+		Err()
+
+	Go(T).AssertNil(e)
+	Go(T).AssertEqual(s, Fixtures["F_STRING"])
+	Go(T).AssertEqual(b, Fixtures["F_BYTES"])
+	Go(T).AssertEqual(d, Fixtures["F_DURATION"])
+	Go(T).AssertEqual(i, Fixtures["F_INT"])
+	Go(T).AssertEqual(i32, Fixtures["F_INT32"])
+	Go(T).AssertEqual(i64, Fixtures["F_INT64"])
+	Go(T).AssertEqual(f32, Fixtures["F_FLOAT32"])
+	Go(T).AssertEqual(f64, Fixtures["F_FLOAT64"])
+	Go(T).AssertEqual(t, Fixtures["F_BOOL"])
+	Go(T).AssertEqual(ip.String(), "127.0.0.1")
+}
+
+func TestChecker_errors(T *testing.T) {
+	defer UnsetFixtures()
+	SetFixtures()
+
+	i := 9
+	c := NewChecker().
+		String("F_MISSING", nil).
+		Int("F_STRING", &i).
+		Int("F_INT", &i, Min(2)).
+		Bool("F_BOOL", nil)
+
+	Go(T).AssertLength(c.Errors(), 3)
+	Go(T).AssertEqual(i, 9)
+
+	e := c.Err()
+	Go(T).Assert(errors.Is(e, ErrMissing))
+	Go(T).Assert(errors.Is(e, ErrParse))
+	Go(T).Assert(errors.Is(e, ErrValidation))
+	Go(T).AssertEqual(e.Error(), `3 environment errors:
+  - missing required string from F_MISSING
+  - invalid int from F_STRING: "string": strconv.ParseInt: parsing "string": invalid syntax
+  - invalid int from F_INT: "1" does not satisfy min=2`)
+}
@@ -0,0 +1,136 @@
+package env
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Decoder is implemented by types which decode themselves from an
+// environment value
+type Decoder interface {
+	Decode(value string) error
+}
+
+// Parser converts an environment value to a value of a registered type
+type Parser func(value string) (interface{}, error)
+
+var (
+	parsersMu sync.RWMutex
+	parsers   = make(map[reflect.Type]Parser)
+
+	decoderType         = reflect.TypeOf((*Decoder)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// RegisterParser registers fn as the parser for values of type t, used by
+// Unmarshal, GetValue and RequireValue. Registered parsers take precedence
+// over Decoder and encoding.TextUnmarshaler implementations.
+//
+// e.g.:
+//
+//	env.RegisterParser(reflect.TypeOf(netip.Prefix{}), func(s string) (interface{}, error) {
+//	    return netip.ParsePrefix(s)
+//	})
+func RegisterParser(t reflect.Type, fn Parser) {
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+
+	if fn == nil {
+		delete(parsers, t)
+		return
+	}
+	parsers[t] = fn
+}
+
+// GetValue gets key and decodes it into the value pointed to by v, leaving v
+// untouched if key isn't set
+func (e *Env) GetValue(key string, v interface{}) error {
+	fv, err := decodeTarget(v)
+	if err != nil {
+		return err
+	}
+
+	str := e.get(key, typeName(fv.Type()))
+	if str == "" {
+		return nil
+	}
+
+	return e.setField(fv, e.name(key), str)
+}
+
+// RequireValue requires key and decodes it into the value pointed to by v
+func (e *Env) RequireValue(key string, v interface{}, rules ...Rule) error {
+	fv, err := decodeTarget(v)
+	if err != nil {
+		return err
+	}
+
+	str, err := e.require(key, typeName(fv.Type()), rules)
+	if err != nil {
+		return err
+	}
+
+	return e.onParseError(e.setField(fv, e.name(key), str))
+}
+
+func decodeTarget(v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return rv, fmt.Errorf("env: decoding requires a non-nil pointer, got %T", v)
+	}
+
+	return rv.Elem(), nil
+}
+
+// decodeCustom decodes str into fv using a registered parser, Decoder or
+// encoding.TextUnmarshaler, returning false if none apply
+func decodeCustom(fv reflect.Value, str string) (bool, error) {
+	parsersMu.RLock()
+	fn, ok := parsers[fv.Type()]
+	parsersMu.RUnlock()
+
+	if ok {
+		val, err := fn(str)
+		if err != nil {
+			return true, err
+		}
+
+		rv := reflect.ValueOf(val)
+		if !rv.IsValid() || !rv.Type().AssignableTo(fv.Type()) {
+			return true, fmt.Errorf("parser for %s returned %T", fv.Type(), val)
+		}
+
+		fv.Set(rv)
+		return true, nil
+	}
+
+	if !fv.CanAddr() {
+		return false, nil
+	}
+
+	switch t := fv.Addr().Interface().(type) {
+	case Decoder:
+		return true, t.Decode(str)
+	case encoding.TextUnmarshaler:
+		return true, t.UnmarshalText([]byte(str))
+	}
+
+	return false, nil
+}
+
+// isCustom reports whether values of type t are decoded by a registered
+// parser, Decoder or encoding.TextUnmarshaler
+func isCustom(t reflect.Type) bool {
+	parsersMu.RLock()
+	_, ok := parsers[t]
+	parsersMu.RUnlock()
+
+	if ok {
+		return true
+	}
+
+	pt := reflect.PtrTo(t)
+	return pt.Implements(decoderType) || pt.Implements(textUnmarshalerType)
+}
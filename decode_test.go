@@ -0,0 +1,143 @@
+package env
+
+import (
+	"errors"
+	"net"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+type level int
+
+func (l *level) Decode(value string) error {
+	switch strings.ToLower(value) {
+	case "debug":
+		*l = 0
+	case "info":
+		*l = 1
+	default:
+		return errors.New("unknown level " + value)
+	}
+	return nil
+}
+
+type upper string
+
+func TestGetValue(T *testing.T) {
+	defer os.Unsetenv("D_LEVEL")
+	defer os.Unsetenv("D_IP")
+
+	l := level(-1)
+	Go(T).AssertNil(GetValue("D_LEVEL", &l))
+	Go(T).AssertEqual(l, level(-1))
+
+	Set("D_LEVEL", "info")
+	Go(T).AssertNil(GetValue("D_LEVEL", &l))
+	Go(T).AssertEqual(l, level(1))
+
+	Set("D_LEVEL", "verbose")
+	Go(T).RefuteNil(GetValue("D_LEVEL", &l))
+
+	// encoding.TextUnmarshaler
+	var ip net.IP
+	Set("D_IP", "127.0.0.1")
+	Go(T).AssertNil(GetValue("D_IP", &ip))
+	Go(T).AssertEqual(ip.String(), "127.0.0.1")
+
+	// builtins
+	var i int64
+	Set("D_LEVEL", "9")
+	Go(T).AssertNil(GetValue("D_LEVEL", &i))
+	Go(T).AssertEqual(i, int64(9))
+
+	Go(T).RefuteNil(GetValue("D_LEVEL", i))
+}
+
+func TestRequireValue(T *testing.T) {
+	defer os.Unsetenv("D_LEVEL")
+
+	var l level
+	err := RequireValue("D_LEVEL", &l)
+	Go(T).RefuteNil(err)
+	Go(T).AssertEqual(err.Error(), "missing required env.level from D_LEVEL")
+
+	Set("D_LEVEL", "debug")
+	Go(T).AssertNil(RequireValue("D_LEVEL", &l))
+	Go(T).AssertEqual(l, level(0))
+}
+
+func TestRequireValue_typeNames(T *testing.T) {
+	defer os.Unsetenv("D_LEVEL")
+
+	var ip net.IP
+	err := RequireValue("D_IP", &ip)
+	Go(T).AssertEqual(err.Error(), "missing required net.IP from D_IP")
+
+	var at *time.Time
+	Set("D_LEVEL", "now")
+	err = RequireValue("D_LEVEL", &at)
+	var perr *ParseError
+	Go(T).Assert(errors.As(err, &perr))
+	Go(T).AssertEqual(perr.Type, "time.Time")
+
+	// rules compare custom types by length rather than by their kind
+	var l level
+	Set("D_LEVEL", "info")
+	Go(T).AssertNil(RequireValue("D_LEVEL", &l, Min(4)))
+	Go(T).AssertEqual(l, level(1))
+}
+
+func TestRegisterParser(T *testing.T) {
+	defer os.Unsetenv("D_UPPER")
+
+	t := reflect.TypeOf(upper(""))
+	RegisterParser(t, func(s string) (interface{}, error) {
+		return upper(strings.ToUpper(s)), nil
+	})
+	defer RegisterParser(t, nil)
+
+	Set("D_UPPER", "shout")
+
+	var u upper
+	Go(T).AssertNil(GetValue("D_UPPER", &u))
+	Go(T).AssertEqual(u, upper("SHOUT"))
+
+	RegisterParser(t, func(s string) (interface{}, error) {
+		return s, nil
+	})
+	Go(T).RefuteNil(GetValue("D_UPPER", &u))
+}
+
+func TestUnmarshal_custom(T *testing.T) {
+	defer os.Unsetenv("LEVEL")
+	defer os.Unsetenv("ADDR_IP")
+	defer os.Unsetenv("AT")
+
+	var c struct {
+		Level  level
+		IP     net.IP `env:"ADDR_IP"`
+		Levels *level `env:"LEVEL"`
+		At     time.Time
+	}
+
+	Set("LEVEL", "info")
+	Set("ADDR_IP", "10.0.0.1")
+	Set("AT", "2015-10-01T00:00:00Z")
+
+	Go(T).AssertNil(Unmarshal(&c))
+	Go(T).AssertEqual(c.Level, level(1))
+	Go(T).AssertEqual(c.IP.String(), "10.0.0.1")
+	Go(T).RefuteNil(c.Levels)
+	Go(T).AssertEqual(*c.Levels, level(1))
+	Go(T).AssertEqual(c.At.Year(), 2015)
+
+	m, err := Marshal(c)
+	Go(T).AssertNil(err)
+	Go(T).AssertEqual(m["ADDR_IP"], "10.0.0.1")
+	Go(T).AssertEqual(m["AT"], "2015-10-01T00:00:00Z")
+}
@@ -0,0 +1,389 @@
+package env
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// dotenvEntry is a key and value parsed from a dotenv file
+//
+// Until it's expanded, the value is held in raw, where backslashes and
+// dollar signs which are part of the value are escaped, leaving unescaped
+// dollar signs to start references, and expand is false for single quoted
+// values.
+type dotenvEntry struct {
+	key    string
+	value  string
+	line   int
+	column int
+	raw    string
+	expand bool
+}
+
+// Lenient makes Load, Overload and NewDotenvFileSource skip malformed lines
+// in dotenv files, passing them to Warn, rather than returning an error
+var Lenient = false
+
+// Warn is passed a *SyntaxError for each malformed line skipped in Lenient
+// mode
+var Warn func(err error)
+
+// ParseDotenv reads dotenv formatted data from r, returning its keys and
+// values, with later keys overriding earlier ones
+//
+// Each line sets a KEY=value, optionally prefixed with `export`, and `#`
+// starts a comment, either on its own line or after whitespace following a
+// value. Values can be:
+//
+//	UNQUOTED=value, trimmed of surrounding whitespace
+//	SINGLE='a literal value, with no escapes'
+//	DOUBLE="supporting \"escapes\" such as \n, \r, \t and \\"
+//	MULTI="quoted values
+//	can span lines"
+//
+// Unquoted and double quoted values can reference other keys in r or the
+// environment, unless DisableExpansion is set:
+//
+//	URL=postgres://${DB_HOST:-localhost}/${DB_NAME:?must be set}
+//	PRICE="\$5"
+//
+// Windows line endings and a leading byte order mark are ignored, and lines
+// can be of any length. Malformed lines are skipped and returned as an
+// Errors of *SyntaxError, along with every value which could be parsed.
+func ParseDotenv(r io.Reader) (map[string]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, problems := decodeDotenv(data, os.LookupEnv, true, DisableExpansion)
+
+	values := make(map[string]string)
+	for key, entry := range lastEntries(entries) {
+		values[key] = entry.value
+	}
+
+	if len(problems) > 0 {
+		return values, syntaxErrors(problems)
+	}
+
+	return values, nil
+}
+
+// readDotenv reads, parses and expands filename, returning an Errors
+// listing any malformed lines unless e is Lenient. References are resolved
+// as described by expander.
+func (e *Env) readDotenv(filename string, lookup func(key string) (string, bool), override bool) ([]dotenvEntry, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, problems := decodeDotenv(data, lookup, override, e.DisableExpansion)
+	for _, problem := range problems {
+		problem.File = filename
+	}
+
+	if len(problems) > 0 && !e.Lenient {
+		return nil, syntaxErrors(problems)
+	}
+
+	if e.Warn != nil {
+		for _, problem := range problems {
+			e.Warn(problem)
+		}
+	}
+
+	return entries, nil
+}
+
+func syntaxErrors(problems []*SyntaxError) Errors {
+	errs := make(Errors, 0, len(problems))
+	for _, problem := range problems {
+		errs = append(errs, problem)
+	}
+
+	return errs
+}
+
+// lastEntries returns the last entry for each key, which is the one that
+// takes effect
+func lastEntries(entries []dotenvEntry) map[string]dotenvEntry {
+	last := make(map[string]dotenvEntry, len(entries))
+	for _, entry := range entries {
+		last[entry.key] = entry
+	}
+
+	return last
+}
+
+// decodeDotenv parses and expands data, returning its entries and any
+// problems in the order they appear
+func decodeDotenv(data []byte, lookup func(key string) (string, bool), override, disable bool) ([]dotenvEntry, []*SyntaxError) {
+	entries, problems := parseDotenv(data)
+
+	entries, unresolved := expandDotenv(entries, lookup, override, disable)
+	problems = append(problems, unresolved...)
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Line < problems[j].Line
+	})
+
+	return entries, problems
+}
+
+// parseDotenv parses data into entries, in the order they're set, skipping
+// and reporting malformed lines
+func parseDotenv(data []byte) ([]dotenvEntry, []*SyntaxError) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+
+	p := &dotenvParser{src: string(data), line: 1}
+	for !p.eof() {
+		p.skipSpace()
+
+		switch {
+		case p.eof():
+		case p.peek() == '\n':
+			p.next()
+		case p.peek() == '#':
+			p.skipLine()
+		default:
+			p.parseEntry()
+		}
+	}
+
+	return p.entries, p.problems
+}
+
+// dotenvParser tokenizes dotenv data, tracking the current line so entries
+// and problems can be located
+type dotenvParser struct {
+	src       string
+	pos       int
+	line      int
+	lineStart int
+
+	entries  []dotenvEntry
+	problems []*SyntaxError
+}
+
+func (p *dotenvParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *dotenvParser) peek() byte {
+	return p.src[p.pos]
+}
+
+// next consumes and returns one byte, tracking line starts
+func (p *dotenvParser) next() byte {
+	c := p.src[p.pos]
+	p.pos++
+
+	if c == '\n' {
+		p.line++
+		p.lineStart = p.pos
+	}
+
+	return c
+}
+
+// skipSpace skips spaces and tabs, but not newlines
+func (p *dotenvParser) skipSpace() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+// skipLine skips to the start of the next line
+func (p *dotenvParser) skipLine() {
+	for !p.eof() && p.next() != '\n' {
+	}
+}
+
+// column returns the column of pos on the current line, counting runes
+func (p *dotenvParser) column(pos int) int {
+	return utf8.RuneCountInString(p.src[p.lineStart:pos]) + 1
+}
+
+// fail records a problem, then skips the rest of the current line
+func (p *dotenvParser) fail(line, column int, reason string) {
+	p.problems = append(p.problems, &SyntaxError{Line: line, Column: column, Reason: reason})
+	p.skipLine()
+}
+
+// parseEntry parses a KEY=value line, starting at its key
+func (p *dotenvParser) parseEntry() {
+	line := p.line
+
+	key := p.parseKey()
+	if key == "export" && !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.skipSpace()
+		key = p.parseKey()
+	}
+
+	if key == "" {
+		p.fail(line, p.column(p.pos), "expected a key")
+		return
+	}
+
+	p.skipSpace()
+	if p.eof() || (p.peek() != '=' && p.peek() != ':') {
+		p.fail(line, p.column(p.pos), "expected = after "+key)
+		return
+	}
+	p.next()
+	p.skipSpace()
+
+	column := p.column(p.pos)
+	raw, expand, ok := p.parseValue()
+	if !ok {
+		return
+	}
+
+	p.entries = append(p.entries, dotenvEntry{key: key, line: line, column: column, raw: raw, expand: expand})
+}
+
+// parseKey consumes a key made of letters, digits, '_', '.' and '-'
+func (p *dotenvParser) parseKey() string {
+	start := p.pos
+	for !p.eof() && isKeyByte(p.peek()) {
+		p.pos++
+	}
+
+	return p.src[start:p.pos]
+}
+
+func isKeyByte(c byte) bool {
+	return c == '_' || c == '.' || c == '-' ||
+		('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// parseValue consumes a value and the rest of its line, returning its raw
+// form, whether it's expanded, and false if it's malformed
+func (p *dotenvParser) parseValue() (string, bool, bool) {
+	if p.eof() {
+		return "", true, true
+	}
+
+	switch p.peek() {
+	case '\'', '"':
+		return p.parseQuoted()
+	}
+
+	return p.parseUnquoted(), true, true
+}
+
+// parseUnquoted consumes an unquoted value up to the end of the line or an
+// inline comment, trimming trailing whitespace. Backslashes are literal,
+// except before a dollar sign.
+func (p *dotenvParser) parseUnquoted() string {
+	start := p.pos
+	end := p.pos
+
+	for !p.eof() && p.peek() != '\n' {
+		c := p.src[p.pos]
+		if c == '#' && (p.pos == start || p.src[p.pos-1] == ' ' || p.src[p.pos-1] == '\t') {
+			break
+		}
+
+		p.pos++
+		end = p.pos
+	}
+	p.skipLine()
+
+	value := strings.TrimRight(p.src[start:end], " \t")
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i+1 < len(value) && value[i+1] == '$':
+			b.WriteString(`\$`)
+			i++
+		case value[i] == '\\':
+			b.WriteString(`\\`)
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+
+	return b.String()
+}
+
+// parseQuoted consumes a single or double quoted value, which may span
+// lines, and the rest of its line, which may only hold a comment
+func (p *dotenvParser) parseQuoted() (string, bool, bool) {
+	start, line, lineStart := p.pos, p.line, p.lineStart
+	quote := p.next()
+
+	var b strings.Builder
+	for {
+		if p.eof() {
+			// only skip the line the value started on, so the lines
+			// following it are still parsed
+			p.pos, p.line, p.lineStart = start, line, lineStart
+			p.fail(line, p.column(start), "unterminated quoted value")
+			return "", false, false
+		}
+
+		c := p.next()
+		if c == quote {
+			break
+		}
+
+		switch {
+		case c == '\\' && quote == '"' && !p.eof():
+			writeLiteral(&b, unescape(p.next()))
+		case c == '$' && quote == '"':
+			// left unescaped to start a reference
+			b.WriteByte(c)
+		default:
+			writeLiteral(&b, string(c))
+		}
+	}
+
+	p.skipSpace()
+	if !p.eof() && p.peek() != '\n' && p.peek() != '#' {
+		p.fail(p.line, p.column(p.pos), "unexpected characters after quoted value")
+		return "", false, false
+	}
+	p.skipLine()
+
+	return b.String(), quote == '"', true
+}
+
+// writeLiteral writes s to b as part of a raw value, escaping backslashes
+// and dollar signs
+func writeLiteral(b *strings.Builder, s string) {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' || s[i] == '$' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+}
+
+// unescape returns the value of an escape sequence in a double quoted
+// value, keeping the backslash of unknown sequences
+func unescape(c byte) string {
+	switch c {
+	case 'n':
+		return "\n"
+	case 'r':
+		return "\r"
+	case 't':
+		return "\t"
+	case '"', '\\', '$', '\'':
+		return string(c)
+	case '\n':
+		// a backslash at the end of a line continues the value
+		return ""
+	}
+
+	return "\\" + string(c)
+}
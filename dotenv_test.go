@@ -0,0 +1,145 @@
+package env
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+func TestParseDotenv(T *testing.T) {
+	values, err := ParseDotenv(strings.NewReader(`
+# a comment
+UNQUOTED=value
+  INDENTED = spaced out   
+EMPTY=
+export EXPORTED=exported
+YAML: style
+DOTTED.KEY-NAME=dotted
+COMMENTED=value # a comment
+HASH=http://example.com/#anchor
+SINGLE='literal \n $HOME "quoted" # kept'
+DOUBLE="escaped \"quotes\"\n\ttab \\ \$HOME # kept" # a comment
+UNKNOWN="\q"
+MULTI="first
+second"
+PEM='-----BEGIN KEY-----
+abc
+-----END KEY-----'
+CONTINUED="one \
+two"
+DUPLICATE=first
+DUPLICATE=second
+`))
+	Go(T).AssertNil(err)
+
+	Go(T).AssertDeepEqual(values, map[string]string{
+		"UNQUOTED":        "value",
+		"INDENTED":        "spaced out",
+		"EMPTY":           "",
+		"EXPORTED":        "exported",
+		"YAML":            "style",
+		"DOTTED.KEY-NAME": "dotted",
+		"COMMENTED":       "value",
+		"HASH":            "http://example.com/#anchor",
+		"SINGLE":          `literal \n $HOME "quoted" # kept`,
+		"DOUBLE":          "escaped \"quotes\"\n\ttab \\ $HOME # kept",
+		"UNKNOWN":         `\q`,
+		"MULTI":           "first\nsecond",
+		"PEM":             "-----BEGIN KEY-----\nabc\n-----END KEY-----",
+		"CONTINUED":       "one two",
+		"DUPLICATE":       "second",
+	})
+}
+
+func TestParseDotenv_crlf(T *testing.T) {
+	values, err := ParseDotenv(strings.NewReader("\xef\xbb\xbfFIRST=1\r\nMULTI=\"a\r\nb\"\r\nLAST='2'\r\n"))
+	Go(T).AssertNil(err)
+	Go(T).AssertDeepEqual(values, map[string]string{"FIRST": "1", "MULTI": "a\nb", "LAST": "2"})
+}
+
+func TestParseDotenv_long(T *testing.T) {
+	long := strings.Repeat("x", 128*1024)
+
+	values, err := ParseDotenv(strings.NewReader("LONG=" + long + "\nNEXT=1"))
+	Go(T).AssertNil(err)
+	Go(T).AssertEqual(values["LONG"], long)
+	Go(T).AssertEqual(values["NEXT"], "1")
+}
+
+func TestParseDotenv_malformed(T *testing.T) {
+	values, err := ParseDotenv(strings.NewReader(`BEFORE=1
+DATABSE_URL postgres://localhost
+=novalue
+TRAILING="quoted" junk
+UNTERMINATED="never closed
+AFTER=2
+`))
+
+	// malformed lines are skipped, and reported
+	Go(T).AssertDeepEqual(values, map[string]string{"BEFORE": "1", "AFTER": "2"})
+	Go(T).Assert(errors.Is(err, ErrSyntax))
+	Go(T).AssertEqual(err.Error(), "4 environment errors:\n"+
+		"  - 2:13: expected = after DATABSE_URL\n"+
+		"  - 3:1: expected a key\n"+
+		"  - 4:19: unexpected characters after quoted value\n"+
+		"  - 5:14: unterminated quoted value")
+}
+
+func TestLoad_malformed(T *testing.T) {
+	T.Parallel()
+
+	src := MapSource{}
+	e := New(src)
+
+	err := e.Load("_fixtures/malformed.env", env)
+	Go(T).RefuteNil(err)
+
+	var errs Errors
+	Go(T).Assert(errors.As(err, &errs))
+	Go(T).AssertDeepEqual(errs, Errors{
+		&SyntaxError{File: "_fixtures/malformed.env", Line: 2, Column: 13, Reason: "expected = after DATABSE_URL"},
+		&SyntaxError{File: "_fixtures/malformed.env", Line: 4, Column: 7, Reason: "unterminated quoted value"},
+	})
+	Go(T).AssertEqual(errs[0].Error(), "_fixtures/malformed.env:2:13: expected = after DATABSE_URL")
+
+	// nothing is loaded from a malformed file
+	Go(T).AssertLength(src, 0)
+
+	_, err = NewDotenvFileSource("_fixtures/malformed.env")
+	Go(T).Assert(errors.Is(err, ErrSyntax))
+}
+
+func TestLoad_lenient(T *testing.T) {
+	T.Parallel()
+
+	src := MapSource{}
+	e := New(src)
+	e.Lenient = true
+
+	warnings := make([]error, 0)
+	e.Warn = func(err error) {
+		warnings = append(warnings, err)
+	}
+
+	Go(T).AssertNil(e.Overload("_fixtures/malformed.env"))
+	Go(T).AssertDeepEqual(src, MapSource{"PORT": "3000", "ADDR": "0.0.0.0"})
+	Go(T).AssertLength(warnings, 2)
+	Go(T).Assert(errors.Is(warnings[1], ErrSyntax))
+}
+
+func Test_parseDotenv(T *testing.T) {
+	entries, problems := parseDotenv([]byte("A=1\nMULTI='x\ny'\nbad line\nB=\"open\nC=3\n"))
+
+	Go(T).AssertDeepEqual(entries, []dotenvEntry{
+		{key: "A", line: 1, column: 3, raw: "1", expand: true},
+		{key: "MULTI", line: 2, column: 7, raw: "x\ny", expand: false},
+		{key: "C", line: 6, column: 3, raw: "3", expand: true},
+	})
+
+	Go(T).AssertDeepEqual(problems, []*SyntaxError{
+		{Line: 4, Column: 5, Reason: "expected = after bad"},
+		{Line: 5, Column: 3, Reason: "unterminated quoted value"},
+	})
+}
@@ -13,14 +13,10 @@
 //
 //     func init() {
 //     	env.PanicOnRequire = true
-//     	var err error
-//     	err = env.Load("_example/example.env")
-//     	if err != nil {
-//     		// work in _example
-//     		err = env.Load("example.env")
-//     		if err != nil {
-//     			panic(err)
-//     		}
+//
+//     	// works from the project root and from _example
+//     	if _, err := env.LoadNearest("_example/example.env"); err != nil {
+//     		panic(err)
 //     	}
 //
 //     	// ensure requires
@@ -44,287 +40,592 @@ package env
 
 import (
 	"fmt"
-	"os"
+	"reflect"
 	"strconv"
-	"strings"
 	"time"
-
-	dotenv "github.com/jmervine/env/Godeps/_workspace/src/github.com/joho/godotenv"
 )
 
 // PanicOnRequire forces panics when Require- methods fail
 var PanicOnRequire = false
 
+// OnRead is called with every key read by the package-level functions, see
+// Observer
+var OnRead Observer
+
+// Observer is called with each key an Env reads, fully qualified, and the
+// type it's read as, e.g. "string", "int" or "duration map". The type is
+// empty for reads which only check whether a key is set, such as IsSet.
+type Observer func(key, typ string)
+
+// Env provides the full Get, Require and GetOrSet API over a Source. The
+// package-level functions use a default Env backed by the process
+// environment, configured by the package-level variables.
+type Env struct {
+	source  Source
+	origins *origins
+	prefix  string
+
+	// PanicOnRequire forces panics when Require- methods fail
+	PanicOnRequire bool
+
+	// Strict forces getters which can't return an error to panic on
+	// malformed values, see the package-level Strict
+	Strict bool
+
+	// AllowEmpty makes Require- and GetOrSet- methods treat keys set to an
+	// empty value as present
+	AllowEmpty bool
+
+	// ListSeparator, PairSeparator and KeyValueSeparator are used to read
+	// and write list and map values, falling back to "," "," and "=" when
+	// empty
+	ListSeparator     string
+	PairSeparator     string
+	KeyValueSeparator string
+
+	// OnRead is called with every key read, see Observer
+	OnRead Observer
+
+	// Lenient makes Load and Overload skip malformed lines in dotenv files,
+	// passing them to Warn, rather than returning an error
+	Lenient bool
+	Warn    func(err error)
+
+	// DisableExpansion turns off the expansion of references in dotenv
+	// files, see ParseDotenv
+	DisableExpansion bool
+}
+
+// New returns an Env reading from and writing to source, or the process
+// environment if source is nil
+//
+// e.g.:
+//
+//	e := env.New(src)
+//	e.PanicOnRequire = true
+//
+//	port := e.GetOrInt("PORT", 3000)
+func New(source Source) *Env {
+	if source == nil {
+		source = OSSource{}
+	}
+
+	return &Env{
+		source:            source,
+		origins:           newOrigins(),
+		ListSeparator:     ",",
+		PairSeparator:     ",",
+		KeyValueSeparator: "=",
+	}
+}
+
+// std returns the default Env used by the package-level functions, with the
+// current values of the package-level variables
+func std() *Env {
+	return &Env{
+		source:            OSSource{},
+		origins:           stdOrigins,
+		PanicOnRequire:    PanicOnRequire,
+		Strict:            Strict,
+		AllowEmpty:        AllowEmpty,
+		ListSeparator:     ListSeparator,
+		PairSeparator:     PairSeparator,
+		KeyValueSeparator: KeyValueSeparator,
+		OnRead:            OnRead,
+		Lenient:           Lenient,
+		Warn:              Warn,
+		DisableExpansion:  DisableExpansion,
+	}
+}
+
+// Source returns the Source e reads from
+func (e *Env) Source() Source {
+	return e.source
+}
+
 // Load loads a file containing standard os environment key/value pairs,
-// doesn't override currently set variables
+// doesn't override currently set variables, including those set to an empty
+// value
 //
-// e.g.: .env
+// Files with malformed lines aren't loaded, instead an Errors listing a
+// *SyntaxError for each is returned, unless Lenient is set.
 //
-//     PORT=3000
-//     ADDR=0.0.0.0
-//     DEBUG=true
+// e.g.: .env
 //
-func Load(filenames ...string) error {
-	return dotenv.Load(filenames...)
+//	PORT=3000
+//	ADDR=0.0.0.0
+//	DEBUG=true
+func (e *Env) Load(filenames ...string) error {
+	if len(filenames) == 0 {
+		filenames = []string{".env"}
+	}
+
+	// files are read one at a time so earlier files take precedence
+	for _, filename := range filenames {
+		if err := e.loadFile(filename, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // Overload does the same thing as Load, but overrides existing variables
-func Overload(filenames ...string) error {
-	env, err := dotenv.Read(filenames...)
+func (e *Env) Overload(filenames ...string) error {
+	if len(filenames) == 0 {
+		filenames = []string{".env"}
+	}
+
+	for _, filename := range filenames {
+		if err := e.loadFile(filename, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadFile sets the keys in filename, recording the line each came from,
+// skipping keys which are already set unless overload is true
+func (e *Env) loadFile(filename string, overload bool) error {
+	entries, err := e.readDotenv(filename, e.source.Lookup, overload)
 	if err != nil {
 		return err
 	}
 
-	for key, val := range env {
-		os.Setenv(key, val)
+	for key, entry := range lastEntries(entries) {
+		if _, ok := e.source.Lookup(key); ok && !overload {
+			continue
+		}
+
+		if err := e.set(key, entry.value); err != nil {
+			return err
+		}
+		e.origins.write(Origin{Key: e.name(key), Value: entry.value, Source: "dotenv", File: filename, Line: entry.line})
 	}
 
 	return nil
 }
 
-// Set sets via an interface
-func Set(key string, val interface{}) {
-	os.Setenv(key, toString(val))
+// Set sets via an interface, returning ErrReadOnly if e's Source doesn't
+// implement Setter
+func (e *Env) Set(key string, val interface{}) error {
+	if err := e.set(key, e.toString(val)); err != nil {
+		return err
+	}
+
+	e.origins.forget(e.name(key))
+	return nil
 }
 
 // SetMap iterates over a map and sets keys to values
-func SetMap(m map[string]interface{}) {
+func (e *Env) SetMap(m map[string]interface{}) error {
 	for key, val := range m {
-		Set(key, val)
+		if err := e.Set(key, val); err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
-// Get gets a key and returns a string
-func Get(key string) string {
-	return os.Getenv(key)
+// Keys returns every key set in e's Source
+func (e *Env) Keys() []string {
+	return e.source.Keys()
 }
 
-// Require gets a key and returns a string or an error if it's set to "" in
-// os.Getenv
-func Require(key string) (val string, err error) {
-	val = Get(key)
-	if val == "" {
-		err = onError(fmt.Errorf("missing required string from %s", key))
-	}
+// Get gets a key and returns a string
+func (e *Env) Get(key string) string {
+	return e.get(key, "string")
+}
 
-	return val, err
+// Require gets a key and returns a string or an error if it's set to "",
+// or if it fails any of the passed validation rules
+//
+// e.g.:
+//
+//	level, err := env.Require("LOG_LEVEL", env.OneOf("debug", "info", "warn"))
+func (e *Env) Require(key string, rules ...Rule) (string, error) {
+	return e.require(key, "string", rules)
 }
 
 // GetOrSet gets a key and returns a string or set's the default
-func GetOrSet(key string, val interface{}) string {
-	str := Get(key)
-
-	if str != "" {
+func (e *Env) GetOrSet(key string, val interface{}) string {
+	if str, ok := e.present(key, "string"); ok {
 		return str
 	}
 
-	v := toString(val)
-	Set(key, v)
+	v := e.toString(val)
+	e.setDefault(key, v)
 
 	return v
 }
 
 // GetString is an alias to Get
-func GetString(key string) string {
-	return Get(key)
+func (e *Env) GetString(key string) string {
+	return e.get(key, "string")
 }
 
-// GetString is an alias to Require
-func RequireString(key string) (string, error) {
-	return Require(key)
+// RequireString is an alias to Require
+func (e *Env) RequireString(key string, rules ...Rule) (string, error) {
+	return e.Require(key, rules...)
 }
 
 // GetOrSetString is an alias to GetOrSet, except it only takes a string
 // as default value
-func GetOrSetString(key, val string) string {
-	return GetOrSet(key, val)
+func (e *Env) GetOrSetString(key, val string) string {
+	return e.GetOrSet(key, val)
 }
 
 // GetBytes gets get and converts value to []byte
-func GetBytes(key string) []byte {
-	return []byte(Get(key))
+func (e *Env) GetBytes(key string) []byte {
+	return []byte(e.get(key, "bytes"))
 }
 
-// GetBytes requires key and converts value to []byte
-func RequireBytes(key string) ([]byte, error) {
-	s, e := Require(key)
-	return []byte(s), e
+// RequireBytes requires key and converts value to []byte
+func (e *Env) RequireBytes(key string, rules ...Rule) ([]byte, error) {
+	s, err := e.require(key, "bytes", rules)
+	return []byte(s), err
 }
 
-// GetBytes gets or sets key and returns value as []byte
-func GetOrSetBytes(key string, val []byte) []byte {
-	return []byte(GetOrSet(key, val))
+// GetOrSetBytes gets or sets key and returns value as []byte
+func (e *Env) GetOrSetBytes(key string, val []byte) []byte {
+	return []byte(e.GetOrSet(key, val))
 }
 
 // GetDuration gets key and returns value as time.Duration
-func GetDuration(key string) time.Duration {
-	return toDur(Get(key))
+func (e *Env) GetDuration(key string) time.Duration {
+	d, err := e.GetDurationE(key)
+	e.strict(err)
+	return d
+}
+
+// GetDurationE gets key and returns value as time.Duration, or an error if
+// it isn't a valid duration
+func (e *Env) GetDurationE(key string) (time.Duration, error) {
+	str := e.get(key, "duration")
+	if str == "" {
+		return time.Duration(0), nil
+	}
+	return parseDur(e.name(key), str)
 }
 
-// GetDuration requires key and returns value as time.Duration
-func RequireDuration(key string) (time.Duration, error) {
-	str, err := Require(key)
+// RequireDuration requires key and returns value as time.Duration
+func (e *Env) RequireDuration(key string, rules ...Rule) (time.Duration, error) {
+	str, err := e.require(key, "duration", rules)
 	if err != nil {
 		d := new(time.Duration)
-		return *d, onError(fmt.Errorf("missing required duration from %s", key))
+		return *d, err
 	}
 
-	return toDur(str), nil
+	d, err := parseDur(e.name(key), str)
+	return d, e.onError(err)
 }
 
-// GetDuration gets or sets key and returns value as time.Duration
-func GetOrSetDuration(key string, val time.Duration) time.Duration {
-	str := Get(key)
-	if str != "" {
-		return toDur(str)
+// GetOrSetDuration gets or sets key and returns value as time.Duration
+func (e *Env) GetOrSetDuration(key string, val time.Duration) time.Duration {
+	if str, ok := e.present(key, "duration"); ok {
+		d, err := parseDur(e.name(key), str)
+		e.strict(err)
+		return d
 	}
 
-	Set(key, val)
+	e.setDefault(key, val)
 	return val
 }
 
 // GetInt gets a key and returns an int
-func GetInt(key string) int {
-	return toInt(Get(key))
+func (e *Env) GetInt(key string) int {
+	i, err := e.GetIntE(key)
+	e.strict(err)
+	return i
+}
+
+// GetIntE gets a key and returns an int, or an error if it isn't a valid int
+func (e *Env) GetIntE(key string) (int, error) {
+	str := e.get(key, "int")
+	if str == "" {
+		return int(0), nil
+	}
+	return parseInt(e.name(key), str)
 }
 
 // GetOrSetInt gets or sets key and returns value as int
-func GetOrSetInt(key string, val int) int {
-	str := Get(key)
-	if str != "" {
-		return toInt(str)
+func (e *Env) GetOrSetInt(key string, val int) int {
+	if str, ok := e.present(key, "int"); ok {
+		i, err := parseInt(e.name(key), str)
+		e.strict(err)
+		return i
 	}
-	Set(key, val)
+	e.setDefault(key, val)
 	return val
 }
 
-func RequireInt(key string) (int, error) {
-	str := Get(key)
-	if str != "" {
-		return toInt(str), nil
+// RequireInt requires key and returns value as int
+func (e *Env) RequireInt(key string, rules ...Rule) (int, error) {
+	str, err := e.require(key, "int", rules)
+	if err != nil {
+		return int(0), err
 	}
 
-	return int(0), onError(fmt.Errorf("missing required int from %s", key))
+	i, err := parseInt(e.name(key), str)
+	return i, e.onError(err)
 }
 
 // GetInt32 gets a key and returns an int32
-func GetInt32(key string) int32 {
-	return toInt32(Get(key))
+func (e *Env) GetInt32(key string) int32 {
+	i, err := e.GetInt32E(key)
+	e.strict(err)
+	return i
 }
 
-func GetOrSetInt32(key string, val int32) int32 {
-	str := Get(key)
-	if str != "" {
-		return toInt32(str)
+// GetInt32E gets a key and returns an int32, or an error if it isn't a
+// valid int32
+func (e *Env) GetInt32E(key string) (int32, error) {
+	str := e.get(key, "int32")
+	if str == "" {
+		return int32(0), nil
 	}
-	Set(key, val)
+	return parseInt32(e.name(key), str)
+}
+
+// GetOrSetInt32 gets or sets key and returns value as int32
+func (e *Env) GetOrSetInt32(key string, val int32) int32 {
+	if str, ok := e.present(key, "int32"); ok {
+		i, err := parseInt32(e.name(key), str)
+		e.strict(err)
+		return i
+	}
+	e.setDefault(key, val)
 	return val
 }
 
-func RequireInt32(key string) (int32, error) {
-	str := Get(key)
-	if str == "" {
-		return int32(0), onError(fmt.Errorf("missing required int32 from %s", key))
+// RequireInt32 requires key and returns value as int32
+func (e *Env) RequireInt32(key string, rules ...Rule) (int32, error) {
+	str, err := e.require(key, "int32", rules)
+	if err != nil {
+		return int32(0), err
 	}
-	return toInt32(str), nil
+	i, err := parseInt32(e.name(key), str)
+	return i, e.onError(err)
 }
 
 // GetInt64 gets a key and returns an int64
-func GetInt64(key string) int64 {
-	return toInt64(Get(key))
+func (e *Env) GetInt64(key string) int64 {
+	i, err := e.GetInt64E(key)
+	e.strict(err)
+	return i
 }
 
-func GetOrSetInt64(key string, val int64) int64 {
-	str := Get(key)
-	if str != "" {
-		return toInt64(str)
+// GetInt64E gets a key and returns an int64, or an error if it isn't a
+// valid int64
+func (e *Env) GetInt64E(key string) (int64, error) {
+	str := e.get(key, "int64")
+	if str == "" {
+		return int64(0), nil
 	}
-	Set(key, val)
+	return parseInt64(e.name(key), str)
+}
+
+// GetOrSetInt64 gets or sets key and returns value as int64
+func (e *Env) GetOrSetInt64(key string, val int64) int64 {
+	if str, ok := e.present(key, "int64"); ok {
+		i, err := parseInt64(e.name(key), str)
+		e.strict(err)
+		return i
+	}
+	e.setDefault(key, val)
 	return val
 }
 
-func RequireInt64(key string) (int64, error) {
-	str := Get(key)
-	if str == "" {
-		return int64(0), onError(fmt.Errorf("missing required int64 from %s", key))
+// RequireInt64 requires key and returns value as int64
+func (e *Env) RequireInt64(key string, rules ...Rule) (int64, error) {
+	str, err := e.require(key, "int64", rules)
+	if err != nil {
+		return int64(0), err
 	}
-	return toInt64(str), nil
+	i, err := parseInt64(e.name(key), str)
+	return i, e.onError(err)
 }
 
 // GetFloat32 gets a key and returns an float32
-func GetFloat32(key string) float32 {
-	return toFloat32(Get(key))
+func (e *Env) GetFloat32(key string) float32 {
+	f, err := e.GetFloat32E(key)
+	e.strict(err)
+	return f
 }
 
-func GetOrSetFloat32(key string, val float32) float32 {
-	str := Get(key)
-	if str != "" {
-		return toFloat32(str)
+// GetFloat32E gets a key and returns a float32, or an error if it isn't a
+// valid float32
+func (e *Env) GetFloat32E(key string) (float32, error) {
+	str := e.get(key, "float32")
+	if str == "" {
+		return float32(0), nil
 	}
-	Set(key, val)
+	return parseFloat32(e.name(key), str)
+}
+
+// GetOrSetFloat32 gets or sets key and returns value as float32
+func (e *Env) GetOrSetFloat32(key string, val float32) float32 {
+	if str, ok := e.present(key, "float32"); ok {
+		f, err := parseFloat32(e.name(key), str)
+		e.strict(err)
+		return f
+	}
+	e.setDefault(key, val)
 	return val
 }
 
-func RequireFloat32(key string) (float32, error) {
-	str := Get(key)
-	if str == "" {
-		return float32(0), onError(fmt.Errorf("missing required float32 from %s", key))
+// RequireFloat32 requires key and returns value as float32
+func (e *Env) RequireFloat32(key string, rules ...Rule) (float32, error) {
+	str, err := e.require(key, "float32", rules)
+	if err != nil {
+		return float32(0), err
 	}
-	return toFloat32(str), nil
+	f, err := parseFloat32(e.name(key), str)
+	return f, e.onError(err)
 }
 
 // GetFloat64 gets a key and returns an float64
-func GetFloat64(key string) float64 {
-	return toFloat64(Get(key))
+func (e *Env) GetFloat64(key string) float64 {
+	f, err := e.GetFloat64E(key)
+	e.strict(err)
+	return f
 }
 
-func GetOrSetFloat64(key string, val float64) float64 {
-	str := Get(key)
-	if str != "" {
-		return toFloat64(str)
+// GetFloat64E gets a key and returns a float64, or an error if it isn't a
+// valid float64
+func (e *Env) GetFloat64E(key string) (float64, error) {
+	str := e.get(key, "float64")
+	if str == "" {
+		return float64(0), nil
 	}
-	Set(key, val)
+	return parseFloat64(e.name(key), str)
+}
+
+// GetOrSetFloat64 gets or sets key and returns value as float64
+func (e *Env) GetOrSetFloat64(key string, val float64) float64 {
+	if str, ok := e.present(key, "float64"); ok {
+		f, err := parseFloat64(e.name(key), str)
+		e.strict(err)
+		return f
+	}
+	e.setDefault(key, val)
 	return val
 }
 
-func RequireFloat64(key string) (float64, error) {
-	str := Get(key)
-	if str == "" {
-		return float64(0), onError(fmt.Errorf("missing required float64 from %s", key))
+// RequireFloat64 requires key and returns value as float64
+func (e *Env) RequireFloat64(key string, rules ...Rule) (float64, error) {
+	str, err := e.require(key, "float64", rules)
+	if err != nil {
+		return float64(0), err
 	}
-	return toFloat64(str), nil
+	f, err := parseFloat64(e.name(key), str)
+	return f, e.onError(err)
 }
 
 // GetBool gets a key and sets to true, false or nil using the Truthy and Falsey
 // variables
-func GetBool(key string) bool {
-	return toBool(Get(key))
+func (e *Env) GetBool(key string) bool {
+	b, err := e.GetBoolE(key)
+	e.strict(err)
+	return b
 }
 
-func GetOrSetBool(key string, val bool) bool {
-	str := Get(key)
-	if str != "" {
-		return toBool(str)
+// GetBoolE gets a key and returns a bool, or an error if it isn't a valid
+// bool
+func (e *Env) GetBoolE(key string) (bool, error) {
+	str := e.get(key, "bool")
+	if str == "" {
+		return false, nil
 	}
-	Set(key, val)
+	return parseBool(e.name(key), str)
+}
+
+// GetOrSetBool gets or sets key and returns value as bool
+func (e *Env) GetOrSetBool(key string, val bool) bool {
+	if str, ok := e.present(key, "bool"); ok {
+		b, err := parseBool(e.name(key), str)
+		e.strict(err)
+		return b
+	}
+	e.setDefault(key, val)
 	return val
 }
 
-func RequireBool(key string) (bool, error) {
-	str := Get(key)
-	if str == "" {
-		return false, onError(fmt.Errorf("missing required bool from %s", key))
+// RequireBool requires key and returns value as bool
+func (e *Env) RequireBool(key string, rules ...Rule) (bool, error) {
+	str, err := e.require(key, "bool", rules)
+	if err != nil {
+		return false, err
 	}
-	return toBool(str), nil
+	b, err := parseBool(e.name(key), str)
+	return b, e.onError(err)
 }
 
 // HELPERS
-func toBool(val string) bool {
-	b, _ := strconv.ParseBool(val)
-	return b
+func (e *Env) set(key, val string) error {
+	s, ok := e.source.(Setter)
+	if !ok {
+		return ErrReadOnly
+	}
+	return s.Set(key, val)
+}
+
+func (e *Env) require(key, typ string, rules []Rule) (string, error) {
+	str, err := e.checkRequired(key, typ, rules)
+	return str, e.onError(err)
+}
+
+// checkRequired does the same thing as require, without honoring
+// PanicOnRequire
+func (e *Env) checkRequired(key, typ string, rules []Rule) (string, error) {
+	str, ok := e.lookup(key, typ)
+	if str == "" && !e.AllowEmpty && !acceptsEmpty(rules) {
+		ok = false
+	}
+
+	if !ok {
+		return str, &MissingError{Key: e.name(key), Type: typ}
+	}
+
+	if err := validate(e.name(key), typ, str, rules); err != nil {
+		return "", err
+	}
+
+	return str, nil
+}
+
+// get returns key's value, reporting it to OnRead as read as typ
+func (e *Env) get(key, typ string) string {
+	str, _ := e.lookup(key, typ)
+	return str
+}
+
+// lookup returns key's value and whether it's set, reporting it to OnRead
+// as read as typ
+func (e *Env) lookup(key, typ string) (string, bool) {
+	if e.OnRead != nil {
+		e.OnRead(e.name(key), typ)
+	}
+	return e.source.Lookup(key)
+}
+
+// present returns key's value and whether it counts as set, honoring
+// AllowEmpty, reporting it to OnRead as read as typ
+func (e *Env) present(key, typ string) (string, bool) {
+	str, ok := e.lookup(key, typ)
+	if !e.AllowEmpty && str == "" {
+		return str, false
+	}
+	return str, ok
 }
 
 func toString(v interface{}) string {
+	return std().toString(v)
+}
+
+func (e *Env) toString(v interface{}) string {
 	switch t := v.(type) {
 	case string:
 		// noop
@@ -333,53 +634,92 @@ func toString(v interface{}) string {
 		// special for []byte
 		return string(t)
 	case []string:
-		// easer eggs for later
-		return strings.Join(t, ",")
+		return joinList(t, e.ListSeparator)
 	case []interface{}:
-		// easer eggs for later
 		strs := make([]string, 0)
 		for _, i := range t {
-			strs = append(strs, toString(i))
+			strs = append(strs, e.toString(i))
+		}
+		return joinList(strs, e.ListSeparator)
+	}
+
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Slice:
+		// other slices, e.g. []int or []time.Duration
+		strs := make([]string, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			strs = append(strs, e.toString(rv.Index(i).Interface()))
 		}
-		return strings.Join(strs, ",")
+		return joinList(strs, e.ListSeparator)
+	case reflect.Map:
+		return e.joinMap(rv)
 	}
 
 	return fmt.Sprintf("%v", v)
 }
 
-func toDur(str string) time.Duration {
-	dur, _ := time.ParseDuration(str)
-	return dur
+// the to- helpers return the zero value along with the underlying strconv
+// or time error when val is malformed
+
+func toBool(val string) (bool, error) {
+	return strconv.ParseBool(val)
 }
 
-func toInt(val string) int {
-	i, _ := strconv.ParseInt(val, 10, 16)
-	return int(i)
+func toDur(val string) (time.Duration, error) {
+	return time.ParseDuration(val)
 }
 
-func toInt32(val string) int32 {
-	i, _ := strconv.ParseInt(val, 10, 32)
-	return int32(i)
+func toInt(val string) (int, error) {
+	i, err := strconv.ParseInt(val, 10, 0)
+	if err != nil {
+		return int(0), err
+	}
+	return int(i), nil
 }
 
-func toInt64(val string) int64 {
-	i, _ := strconv.ParseInt(val, 10, 64)
-	return int64(i)
+func toInt32(val string) (int32, error) {
+	i, err := strconv.ParseInt(val, 10, 32)
+	if err != nil {
+		return int32(0), err
+	}
+	return int32(i), nil
 }
 
-func toFloat32(val string) float32 {
-	i, _ := strconv.ParseFloat(val, 32)
-	return float32(i)
+func toInt64(val string) (int64, error) {
+	i, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return int64(0), err
+	}
+	return int64(i), nil
 }
 
-func toFloat64(val string) float64 {
-	i, _ := strconv.ParseFloat(val, 64)
-	return float64(i)
+func toFloat32(val string) (float32, error) {
+	i, err := strconv.ParseFloat(val, 32)
+	if err != nil {
+		return float32(0), err
+	}
+	return float32(i), nil
 }
 
-func onError(e error) error {
-	if PanicOnRequire {
-		panic(e)
+func toFloat64(val string) (float64, error) {
+	i, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return float64(0), err
+	}
+	return float64(i), nil
+}
+
+func onError(err error) error {
+	return std().onError(err)
+}
+
+func (e *Env) onError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if e.PanicOnRequire {
+		panic(err)
 	}
-	return e
+	return err
 }
@@ -19,7 +19,17 @@ func init() {
 }
 
 func TestLoad(T *testing.T) {
-	T.Skip("not testing godotenv internals")
+	defer UnsetFixtures()
+	os.Setenv("F_INT", "999")
+
+	err := Load(env)
+	Go(T).AssertNil(err)
+
+	// ensure no clobber
+	Go(T).AssertEqual(os.Getenv("F_INT"), "999")
+	Go(T).AssertEqual(os.Getenv("F_STRING"), "sample file")
+
+	Go(T).RefuteNil(Load("missing.env"))
 }
 
 func TestOverload(T *testing.T) {
@@ -430,6 +440,88 @@ func TestRequireBool(T *testing.T) {
 	Go(T).AssertNil(e)
 }
 
+func TestNew(T *testing.T) {
+	T.Parallel()
+
+	e := New(nil)
+	Go(T).AssertEqual(e.Source(), OSSource{})
+	Go(T).AssertEqual(e.ListSeparator, ",")
+
+	src := MapSource{"PORT": "3000"}
+	e = New(src)
+	Go(T).AssertEqual(e.GetInt("PORT"), 3000)
+
+	e.Set("ADDR", "0.0.0.0")
+	Go(T).AssertEqual(src["ADDR"], "0.0.0.0")
+	Go(T).AssertEqual(os.Getenv("ADDR"), "")
+}
+
+func TestEnv(T *testing.T) {
+	T.Parallel()
+
+	src := MapSource{}
+	e := New(src)
+
+	_, err := e.RequireInt("F_INT")
+	Go(T).RefuteNil(err)
+
+	Go(T).AssertEqual(e.GetOrSetInt("F_INT", 2), 2)
+	Go(T).AssertEqual(src["F_INT"], "2")
+
+	Go(T).AssertEqual(e.GetOrInt("F_INT32", 3), 3)
+	_, ok := src["F_INT32"]
+	Go(T).Refute(ok)
+
+	e.SetMap(Fixtures)
+	for key := range Fixtures {
+		_, ok := os.LookupEnv(key)
+		Go(T).Refute(ok)
+	}
+
+	var c fixtureConfig
+	Go(T).AssertNil(e.Unmarshal(&c))
+	Go(T).AssertEqual(c.Int, Fixtures["F_INT"])
+	Go(T).AssertEqual(c.Duration, Fixtures["F_DURATION"])
+
+	Go(T).AssertNil(e.Overload(env))
+	Go(T).AssertEqual(e.GetInt("F_INT"), 9)
+	_, ok = os.LookupEnv("F_INT")
+	Go(T).Refute(ok)
+}
+
+func TestEnv_settings(T *testing.T) {
+	T.Parallel()
+
+	e := New(MapSource{"S_INT": "80a", "S_EMPTY": "", "S_LIST": "a;b"})
+
+	Go(T).AssertEqual(e.GetInt("S_INT"), 0)
+	Go(T).AssertEqual(e.GetOr("S_EMPTY", "default"), "default")
+	Go(T).AssertDeepEqual(e.GetStrings("S_LIST"), []string{"a;b"})
+
+	e.AllowEmpty = true
+	e.ListSeparator = ";"
+	Go(T).AssertEqual(e.GetOr("S_EMPTY", "default"), "")
+	Go(T).AssertDeepEqual(e.GetStrings("S_LIST"), []string{"a", "b"})
+
+	// package-level settings don't leak into instances
+	Go(T).Refute(PanicOnRequire)
+	e.PanicOnRequire = true
+	func() {
+		defer func() {
+			Go(T).RefuteNil(recover())
+		}()
+		e.Require("S_MISSING")
+	}()
+
+	e.Strict = true
+	func() {
+		defer func() {
+			Go(T).RefuteNil(recover())
+		}()
+		e.GetInt("S_INT")
+	}()
+}
+
 func Test_toString(T *testing.T) {
 	Go(T).AssertEqual(toString(9), "9")
 
@@ -545,3 +637,21 @@ func ExampleOverload() {
 	// F_BOOL    ::: false
 	// F_INT     ::: 9
 }
+
+func TestEnv_OnRead(T *testing.T) {
+	T.Parallel()
+
+	reads := make([]string, 0)
+	e := New(MapSource{"PORT": "3000"})
+	e.OnRead = func(key, typ string) {
+		reads = append(reads, key+":"+typ)
+	}
+
+	e.GetInt("PORT")
+	e.GetOrDurations("TTL", nil)
+	e.RequireIntMap("LIMITS")
+	e.IsSet("DEBUG")
+	e.WithPrefix("DB_").Get("HOST")
+
+	Go(T).AssertDeepEqual(reads, []string{"PORT:int", "TTL:durations", "LIMITS:int map", "DEBUG:", "DB_HOST:string"})
+}
@@ -0,0 +1,88 @@
+// Package envtest provides helpers for tests which change the process
+// environment, restoring it once the test and its subtests finish.
+//
+// e.g.:
+//
+//	func TestServer(t *testing.T) {
+//	    envtest.Setenv(t, map[string]string{"PORT": "3000"})
+//	    envtest.Load(t, "_fixtures/fixtures.env")
+//	    ...
+//	}
+//
+// As they change the process environment, these helpers must not be used by
+// parallel tests, which should read from an env.New(env.MapSource{...})
+// instead.
+package envtest
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jmervine/env"
+)
+
+// Preserve snapshots the entire environment and restores it when t finishes
+func Preserve(t testing.TB) {
+	t.Helper()
+
+	snapshot := os.Environ()
+	t.Cleanup(func() {
+		restore(snapshot)
+	})
+}
+
+// Setenv sets every key in vars until t finishes, when the entire
+// environment is restored
+func Setenv(t testing.TB, vars map[string]string) {
+	t.Helper()
+	Preserve(t)
+
+	for key, val := range vars {
+		if err := os.Setenv(key, val); err != nil {
+			t.Fatalf("envtest: setting %s: %v", key, err)
+		}
+	}
+}
+
+// Unsetenv unsets keys until t finishes, when the entire environment is
+// restored
+func Unsetenv(t testing.TB, keys ...string) {
+	t.Helper()
+	Preserve(t)
+
+	for _, key := range keys {
+		os.Unsetenv(key)
+	}
+}
+
+// Clearenv clears the entire environment until t finishes, when it's
+// restored
+func Clearenv(t testing.TB) {
+	t.Helper()
+	Preserve(t)
+
+	os.Clearenv()
+}
+
+// Load loads dotenv files with env.Overload until t finishes, when the
+// entire environment is restored, failing t if they can't be read
+func Load(t testing.TB, filenames ...string) {
+	t.Helper()
+	Preserve(t)
+
+	if err := env.Overload(filenames...); err != nil {
+		t.Fatalf("envtest: loading %v: %v", filenames, err)
+	}
+}
+
+// restore replaces the environment with snapshot, as returned by os.Environ
+func restore(snapshot []string) {
+	os.Clearenv()
+
+	for _, kv := range snapshot {
+		if key, val, ok := strings.Cut(kv, "="); ok && key != "" {
+			os.Setenv(key, val)
+		}
+	}
+}
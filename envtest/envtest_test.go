@@ -0,0 +1,68 @@
+package envtest
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+var fixtures = "../_fixtures/fixtures.env"
+
+func TestSetenv(T *testing.T) {
+	os.Setenv("ENVTEST_KEPT", "kept")
+	defer os.Unsetenv("ENVTEST_KEPT")
+
+	T.Run("set", func(t *testing.T) {
+		Setenv(t, map[string]string{"ENVTEST_SET": "set", "ENVTEST_KEPT": "changed"})
+
+		Go(t).AssertEqual(os.Getenv("ENVTEST_SET"), "set")
+		Go(t).AssertEqual(os.Getenv("ENVTEST_KEPT"), "changed")
+
+		// changes made directly are undone too
+		os.Setenv("ENVTEST_LEAKED", "leaked")
+	})
+
+	_, ok := os.LookupEnv("ENVTEST_SET")
+	Go(T).Refute(ok)
+	_, ok = os.LookupEnv("ENVTEST_LEAKED")
+	Go(T).Refute(ok)
+	Go(T).AssertEqual(os.Getenv("ENVTEST_KEPT"), "kept")
+}
+
+func TestUnsetenv(T *testing.T) {
+	os.Setenv("ENVTEST_KEPT", "kept")
+	defer os.Unsetenv("ENVTEST_KEPT")
+
+	T.Run("unset", func(t *testing.T) {
+		Unsetenv(t, "ENVTEST_KEPT")
+
+		_, ok := os.LookupEnv("ENVTEST_KEPT")
+		Go(t).Refute(ok)
+	})
+
+	Go(T).AssertEqual(os.Getenv("ENVTEST_KEPT"), "kept")
+}
+
+func TestClearenv(T *testing.T) {
+	before := os.Environ()
+
+	T.Run("clear", func(t *testing.T) {
+		Clearenv(t)
+		Go(t).AssertLength(os.Environ(), 0)
+	})
+
+	Go(T).AssertDeepEqual(os.Environ(), before)
+}
+
+func TestLoad(T *testing.T) {
+	T.Run("load", func(t *testing.T) {
+		Load(t, fixtures)
+
+		Go(t).AssertEqual(os.Getenv("F_INT"), "9")
+		Go(t).AssertEqual(os.Getenv("F_STRING"), "sample file")
+	})
+
+	_, ok := os.LookupEnv("F_INT")
+	Go(T).Refute(ok)
+}
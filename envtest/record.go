@@ -0,0 +1,214 @@
+package envtest
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jmervine/env"
+)
+
+// Recorder records the keys read through env, and the types they're read as
+type Recorder struct {
+	mu       sync.Mutex
+	declared map[string]bool
+	types    map[string][]string
+}
+
+var (
+	recordersMu sync.Mutex
+	recorders   = make(map[testing.TB]*Recorder)
+)
+
+// Record records every key read by the package-level env functions until t
+// finishes, returning the Recorder so Env instances can report to it too
+// with e.OnRead = r.Observe
+//
+// When t finishes, it fails if any key was read as more than one type, e.g.
+// by both GetInt and GetBool, or if declared isn't empty and any other key
+// was read.
+func Record(t testing.TB, declared ...string) *Recorder {
+	t.Helper()
+
+	r := &Recorder{
+		declared: make(map[string]bool),
+		types:    make(map[string][]string),
+	}
+	for _, key := range declared {
+		r.declared[key] = true
+	}
+
+	prev := env.OnRead
+	env.OnRead = func(key, typ string) {
+		r.Observe(key, typ)
+		if prev != nil {
+			prev(key, typ)
+		}
+	}
+
+	recordersMu.Lock()
+	recorders[t] = r
+	recordersMu.Unlock()
+
+	t.Cleanup(func() {
+		env.OnRead = prev
+
+		recordersMu.Lock()
+		delete(recorders, t)
+		recordersMu.Unlock()
+
+		r.check(t)
+	})
+
+	return r
+}
+
+// Observe records that key was read as typ, it's an env.Observer
+func (r *Recorder) Observe(key, typ string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	types := r.types[key]
+	if types == nil {
+		types = make([]string, 0, 1)
+	}
+
+	if typ != "" && !contains(types, typ) {
+		types = append(types, typ)
+	}
+	r.types[key] = types
+}
+
+// Read reports whether key has been read
+func (r *Recorder) Read(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.types[key]
+	return ok
+}
+
+// Keys returns the sorted keys which have been read
+func (r *Recorder) Keys() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys := make([]string, 0, len(r.types))
+	for key := range r.types {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Types returns the types key has been read as, in the order they were
+// first read
+func (r *Recorder) Types(key string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.types[key]...)
+}
+
+// check fails t for undeclared keys and keys read as conflicting types
+func (r *Recorder) check(t testing.TB) {
+	t.Helper()
+
+	for _, key := range r.Keys() {
+		if len(r.declared) > 0 && !r.declared[key] {
+			t.Errorf("envtest: read undeclared key %s", key)
+		}
+
+		if types := typed(r.Types(key)); len(types) > 1 {
+			t.Errorf("envtest: %s read as conflicting types: %s", key, strings.Join(types, ", "))
+		}
+	}
+}
+
+// AssertRead fails t unless every key has been read since Record(t)
+func AssertRead(t testing.TB, keys ...string) {
+	t.Helper()
+
+	r := recorder(t)
+	if r == nil {
+		return
+	}
+
+	for _, key := range keys {
+		if !r.Read(key) {
+			t.Errorf("envtest: expected %s to be read", key)
+		}
+	}
+}
+
+// AssertNotRead fails t if any key has been read since Record(t)
+func AssertNotRead(t testing.TB, keys ...string) {
+	t.Helper()
+
+	r := recorder(t)
+	if r == nil {
+		return
+	}
+
+	for _, key := range keys {
+		if r.Read(key) {
+			t.Errorf("envtest: expected %s not to be read", key)
+		}
+	}
+}
+
+// AssertReadAs fails t unless key has been read as typ since Record(t),
+// e.g. AssertReadAs(t, "PORT", "int")
+func AssertReadAs(t testing.TB, key, typ string) {
+	t.Helper()
+
+	r := recorder(t)
+	if r == nil {
+		return
+	}
+
+	if types := r.Types(key); !contains(types, typ) {
+		t.Errorf("envtest: expected %s to be read as %s, read as: %s", key, typ, strings.Join(types, ", "))
+	}
+}
+
+// recorder returns t's Recorder, failing t if there isn't one
+func recorder(t testing.TB) *Recorder {
+	t.Helper()
+
+	recordersMu.Lock()
+	r, ok := recorders[t]
+	recordersMu.Unlock()
+
+	if !ok {
+		t.Fatalf("envtest: Record(t) must be called before asserting reads")
+		return nil
+	}
+
+	return r
+}
+
+// typed returns types without "string" and "bytes", which every value can
+// be read as
+func typed(types []string) []string {
+	out := make([]string, 0, len(types))
+	for _, typ := range types {
+		if typ != "string" && typ != "bytes" {
+			out = append(out, typ)
+		}
+	}
+
+	return out
+}
+
+func contains(strs []string, s string) bool {
+	for _, str := range strs {
+		if str == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,95 @@
+package envtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jmervine/env"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+// fakeT collects failures and cleanups instead of failing the test
+type fakeT struct {
+	testing.TB
+	errors   []string
+	cleanups []func()
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeT) Fatalf(format string, args ...interface{}) {
+	t.Errorf(format, args...)
+}
+
+func (t *fakeT) Cleanup(f func()) {
+	t.cleanups = append(t.cleanups, f)
+}
+
+func (t *fakeT) finish() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+func TestRecord(T *testing.T) {
+	Setenv(T, map[string]string{"PORT": "3000", "DEBUG": "true"})
+	Record(T, "PORT", "DEBUG", "MISSING", "TTL")
+
+	env.GetInt("PORT")
+	env.GetOrBool("DEBUG", false)
+	env.Require("MISSING")
+
+	AssertRead(T, "PORT", "DEBUG", "MISSING")
+	AssertReadAs(T, "PORT", "int")
+	AssertReadAs(T, "DEBUG", "bool")
+	AssertNotRead(T, "TTL")
+}
+
+func TestRecord_instance(T *testing.T) {
+	r := Record(T)
+
+	e := env.New(env.MapSource{"DB_HOST": "localhost"}).WithPrefix("DB_")
+	e.OnRead = r.Observe
+
+	e.GetStrings("HOST")
+	e.IsSet("PORT")
+
+	Go(T).AssertDeepEqual(r.Keys(), []string{"DB_HOST", "DB_PORT"})
+	Go(T).AssertDeepEqual(r.Types("DB_HOST"), []string{"strings"})
+	Go(T).AssertLength(r.Types("DB_PORT"), 0)
+	AssertRead(T, "DB_HOST", "DB_PORT")
+}
+
+func TestRecord_failures(T *testing.T) {
+	t := &fakeT{TB: T}
+	r := Record(t, "DEBUG")
+
+	env.Get("DEBUG")
+	env.GetInt("DEBUG")
+	env.GetBool("DEBUG")
+	env.GetDuration("TTL")
+
+	AssertRead(t, "PORT")
+	AssertNotRead(t, "DEBUG")
+	AssertReadAs(t, "DEBUG", "duration")
+	Go(T).AssertDeepEqual(r.Types("DEBUG"), []string{"string", "int", "bool"})
+
+	t.finish()
+	Go(T).AssertNil(env.OnRead)
+
+	Go(T).AssertDeepEqual(t.errors, []string{
+		"envtest: expected PORT to be read",
+		"envtest: expected DEBUG not to be read",
+		"envtest: expected DEBUG to be read as duration, read as: string, int, bool",
+		"envtest: DEBUG read as conflicting types: int, bool",
+		"envtest: read undeclared key TTL",
+	})
+
+	AssertRead(t, "DEBUG")
+	Go(T).AssertEqual(t.errors[len(t.errors)-1], "envtest: Record(t) must be called before asserting reads")
+}
@@ -0,0 +1,102 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrMissing is matched by errors.Is for every *MissingError
+	ErrMissing = errors.New("env: missing required value")
+
+	// ErrParse is matched by errors.Is for every *ParseError
+	ErrParse = errors.New("env: malformed value")
+
+	// ErrValidation is matched by errors.Is for every *ValidationError
+	ErrValidation = errors.New("env: invalid value")
+
+	// ErrSyntax is matched by errors.Is for every *SyntaxError
+	ErrSyntax = errors.New("env: malformed dotenv line")
+)
+
+// MissingError is returned when a required key isn't set
+type MissingError struct {
+	Key  string
+	Type string
+}
+
+func (e *MissingError) Error() string {
+	return fmt.Sprintf("missing required %s from %s", e.Type, e.Key)
+}
+
+// Is reports whether target is ErrMissing
+func (e *MissingError) Is(target error) bool {
+	return target == ErrMissing
+}
+
+// ParseError is returned when a value can't be converted to the expected
+// type
+type ParseError struct {
+	Key   string
+	Type  string
+	Value string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid %s from %s: %q: %v", e.Type, e.Key, e.Value, e.Err)
+}
+
+// Is reports whether target is ErrParse
+func (e *ParseError) Is(target error) bool {
+	return target == ErrParse
+}
+
+// Unwrap returns the underlying conversion error, e.g. strconv.ErrSyntax
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError is returned when a value doesn't satisfy a Rule
+type ValidationError struct {
+	Key   string
+	Type  string
+	Value string
+	Err   error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s from %s: %q does not satisfy %v", e.Type, e.Key, e.Value, e.Err)
+}
+
+// Is reports whether target is ErrValidation
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Unwrap returns the error returned by the failing Rule
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// SyntaxError is returned for each malformed line in a dotenv file
+type SyntaxError struct {
+	File   string
+	Line   int
+	Column int
+	Reason string
+}
+
+// Error formats e as file:line:column: reason, leaving out the file when
+// it's unknown, as for ParseDotenv
+func (e *SyntaxError) Error() string {
+	if e.File == "" {
+		return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Reason)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Reason)
+}
+
+// Is reports whether target is ErrSyntax
+func (e *SyntaxError) Is(target error) bool {
+	return target == ErrSyntax
+}
@@ -0,0 +1,60 @@
+package env
+
+import (
+	. "github.com/jmervine/env/_fixtures"
+
+	"errors"
+	"strconv"
+	"testing"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+func TestMissingError(T *testing.T) {
+	defer UnsetFixtures()
+
+	_, e := RequireInt("F_INT")
+	Go(T).Assert(errors.Is(e, ErrMissing))
+	Go(T).Refute(errors.Is(e, ErrParse))
+
+	var me *MissingError
+	Go(T).Assert(errors.As(e, &me))
+	Go(T).AssertEqual(me.Key, "F_INT")
+	Go(T).AssertEqual(me.Type, "int")
+
+	var c struct {
+		Port int `env:"F_INT" required:"true"`
+	}
+	Go(T).Assert(errors.Is(Unmarshal(&c), ErrMissing))
+}
+
+func TestParseError(T *testing.T) {
+	defer UnsetFixtures()
+	SetFixtures()
+
+	_, e := RequireInt("F_STRING")
+	Go(T).Assert(errors.Is(e, ErrParse))
+	Go(T).Assert(errors.Is(e, strconv.ErrSyntax))
+	Go(T).Refute(errors.Is(e, ErrMissing))
+
+	var pe *ParseError
+	Go(T).Assert(errors.As(e, &pe))
+	Go(T).AssertEqual(pe.Key, "F_STRING")
+	Go(T).AssertEqual(pe.Type, "int")
+	Go(T).AssertEqual(pe.Value, "string")
+}
+
+func TestValidationError(T *testing.T) {
+	defer UnsetFixtures()
+	SetFixtures()
+
+	_, e := RequireInt("F_INT", Min(2))
+	Go(T).Assert(errors.Is(e, ErrValidation))
+	Go(T).Refute(errors.Is(e, ErrParse))
+
+	var ve *ValidationError
+	Go(T).Assert(errors.As(e, &ve))
+	Go(T).AssertEqual(ve.Key, "F_INT")
+	Go(T).AssertEqual(ve.Value, "1")
+	Go(T).AssertEqual(ve.Err.Error(), "min=2")
+}
@@ -0,0 +1,223 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DisableExpansion turns off the expansion of references in dotenv files,
+// leaving values such as "${HOME}/bin" as they are
+var DisableExpansion = false
+
+// expander resolves the references in the values of a dotenv file's
+// entries
+//
+// A reference to a key resolves to its latest definition earlier in the
+// file, or if there isn't one its value from lookup, which returns
+// previously loaded values and the environment. Unless override is set,
+// values from lookup take precedence, as keys which are already set aren't
+// loaded. As references only resolve to earlier definitions, they can't be
+// cyclic.
+type expander struct {
+	entries  []dotenvEntry
+	lookup   func(key string) (string, bool)
+	override bool
+
+	values []string
+	errs   []error
+}
+
+// expandDotenv sets the value of each entry, returning the entries whose
+// references could be resolved, and a *SyntaxError for each of the others
+func expandDotenv(entries []dotenvEntry, lookup func(key string) (string, bool), override, disable bool) ([]dotenvEntry, []*SyntaxError) {
+	x := &expander{
+		entries:  entries,
+		lookup:   lookup,
+		override: override,
+		values:   make([]string, len(entries)),
+		errs:     make([]error, len(entries)),
+	}
+
+	expanded := make([]dotenvEntry, 0, len(entries))
+	problems := make([]*SyntaxError, 0)
+
+	for i, entry := range entries {
+		x.values[i], x.errs[i] = x.expand(i, entry.raw, entry.expand && !disable)
+		if x.errs[i] != nil {
+			problems = append(problems, &SyntaxError{Line: entry.line, Column: entry.column, Reason: x.errs[i].Error()})
+			continue
+		}
+
+		entry.value = x.values[i]
+		expanded = append(expanded, entry)
+	}
+
+	return expanded, problems
+}
+
+// ref returns the value of key as referenced by entry i
+func (x *expander) ref(i int, key string) (string, bool, error) {
+	if !x.override {
+		if val, ok := x.lookup(key); ok {
+			return val, true, nil
+		}
+	}
+
+	for j := i - 1; j >= 0; j-- {
+		if x.entries[j].key == key {
+			return x.values[j], true, x.errs[j]
+		}
+	}
+
+	val, ok := x.lookup(key)
+	return val, ok, nil
+}
+
+// expand unescapes raw, replacing references with their values if expand
+// is set
+func (x *expander) expand(i int, raw string, expand bool) (string, error) {
+	var b strings.Builder
+
+	for n := 0; n < len(raw); n++ {
+		c := raw[n]
+
+		switch {
+		case c == '\\' && n+1 < len(raw):
+			n++
+			b.WriteByte(raw[n])
+
+		case c == '$' && expand && n+1 < len(raw) && raw[n+1] == '{':
+			end := closingBrace(raw, n+2)
+			if end < 0 {
+				return "", errors.New("unterminated ${")
+			}
+
+			val, err := x.substitute(i, raw[n+2:end])
+			if err != nil {
+				return "", err
+			}
+
+			b.WriteString(val)
+			n = end
+
+		case c == '$' && expand && n+1 < len(raw) && isNameStart(raw[n+1]):
+			end := n + 1
+			for end < len(raw) && isNameByte(raw[end]) {
+				end++
+			}
+
+			val, _, err := x.ref(i, raw[n+1:end])
+			if err != nil {
+				return "", err
+			}
+
+			b.WriteString(val)
+			n = end - 1
+
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// substitute returns the value of the expression inside ${...}, which is a
+// key optionally followed by an operator and a word:
+//
+//	${KEY:-word}  word if KEY is unset or empty, ${KEY-word} only if unset
+//	${KEY:+word}  word if KEY is set and not empty, ${KEY+word} if set
+//	${KEY:?word}  fails with word if KEY is unset or empty, ${KEY?word} if unset
+func (x *expander) substitute(i int, expr string) (string, error) {
+	end := 0
+	for end < len(expr) && (isNameByte(expr[end]) || expr[end] == '.') {
+		end++
+	}
+
+	key, op := expr[:end], expr[end:]
+	if key == "" {
+		return "", fmt.Errorf("bad substitution: ${%s}", expr)
+	}
+
+	val, ok, err := x.ref(i, key)
+	if err != nil {
+		return "", err
+	}
+
+	if op == "" {
+		return val, nil
+	}
+
+	colon := strings.HasPrefix(op, ":")
+	if colon {
+		op = op[1:]
+		ok = ok && val != ""
+	}
+
+	if op == "" {
+		return "", fmt.Errorf("bad substitution: ${%s}", expr)
+	}
+
+	word := op[1:]
+	switch op[0] {
+	case '-':
+		if ok {
+			return val, nil
+		}
+		return x.expand(i, word, true)
+
+	case '+':
+		if ok {
+			return x.expand(i, word, true)
+		}
+		return "", nil
+
+	case '?':
+		if ok {
+			return val, nil
+		}
+
+		msg, err := x.expand(i, word, true)
+		if err != nil {
+			return "", err
+		}
+		if msg == "" {
+			msg = "required but not set"
+		}
+		return "", fmt.Errorf("%s: %s", key, msg)
+	}
+
+	return "", fmt.Errorf("bad substitution: ${%s}", expr)
+}
+
+// closingBrace returns the index of the brace closing a ${ whose contents
+// start at start, allowing nested references, or -1
+func closingBrace(raw string, start int) int {
+	depth := 1
+
+	for n := start; n < len(raw); n++ {
+		switch {
+		case raw[n] == '\\':
+			n++
+		case raw[n] == '$' && n+1 < len(raw) && raw[n+1] == '{':
+			depth++
+			n++
+		case raw[n] == '}':
+			depth--
+			if depth == 0 {
+				return n
+			}
+		}
+	}
+
+	return -1
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isNameByte(c byte) bool {
+	return isNameStart(c) || ('0' <= c && c <= '9')
+}
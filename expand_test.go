@@ -0,0 +1,125 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+func TestParseDotenv_expansion(T *testing.T) {
+	defer os.Unsetenv("F_EXPAND_HOST")
+	os.Setenv("F_EXPAND_HOST", "db.example.com")
+
+	values, err := ParseDotenv(strings.NewReader(`
+USER=admin
+EMPTY=
+NAME=app
+URL=postgres://$USER@${F_EXPAND_HOST}/${NAME}
+FORWARD=${LATER:-unset}
+LATER=set
+DEFAULT=${MISSING:-fallback} ${EMPTY:-empty} ${EMPTY-unset}
+NESTED=${MISSING:-${USER:+set}}
+ALTERNATE=${USER:+yes}${MISSING:+no}${EMPTY+set}
+SINGLE='${USER}'
+ESCAPED="\${USER} \\$USER"
+UNQUOTED=\$USER
+LITERAL=$ $1 100$
+PATH_LIKE=/bin
+PATH_LIKE=${PATH_LIKE}:/usr/bin
+`))
+	Go(T).AssertNil(err)
+
+	Go(T).AssertEqual(values["URL"], "postgres://admin@db.example.com/app")
+	Go(T).AssertEqual(values["FORWARD"], "unset")
+	Go(T).AssertEqual(values["DEFAULT"], "fallback empty ")
+	Go(T).AssertEqual(values["NESTED"], "set")
+	Go(T).AssertEqual(values["ALTERNATE"], "yesset")
+	Go(T).AssertEqual(values["SINGLE"], "${USER}")
+	Go(T).AssertEqual(values["ESCAPED"], `${USER} \admin`)
+	Go(T).AssertEqual(values["UNQUOTED"], "$USER")
+	Go(T).AssertEqual(values["LITERAL"], "$ $1 100$")
+	Go(T).AssertEqual(values["PATH_LIKE"], "/bin:/usr/bin")
+}
+
+func TestParseDotenv_expansionErrors(T *testing.T) {
+	values, err := ParseDotenv(strings.NewReader(`REQUIRED=${MISSING:?must be set}
+BARE=${MISSING?}
+OPEN=${A
+BAD=${:-x}
+OK=ok
+`))
+
+	Go(T).AssertDeepEqual(values, map[string]string{"OK": "ok"})
+	Go(T).Assert(errors.Is(err, ErrSyntax))
+	Go(T).AssertEqual(err.Error(), "4 environment errors:\n"+
+		"  - 1:10: MISSING: must be set\n"+
+		"  - 2:6: MISSING: required but not set\n"+
+		"  - 3:6: unterminated ${\n"+
+		"  - 4:5: bad substitution: ${:-x}")
+}
+
+func TestParseDotenv_noForwardReferences(T *testing.T) {
+	values, err := ParseDotenv(strings.NewReader(`A=${B}
+B=$A
+C=$C
+`))
+
+	Go(T).AssertNil(err)
+	Go(T).AssertDeepEqual(values, map[string]string{"A": "", "B": "", "C": ""})
+}
+
+func TestParseDotenv_disableExpansion(T *testing.T) {
+	DisableExpansion = true
+	defer func() {
+		DisableExpansion = false
+	}()
+
+	values, err := ParseDotenv(strings.NewReader(`A=${B} \$C
+B="$A"`))
+	Go(T).AssertNil(err)
+	Go(T).AssertDeepEqual(values, map[string]string{"A": "${B} $C", "B": "$A"})
+}
+
+func TestLoad_expansion(T *testing.T) {
+	T.Parallel()
+
+	file := T.TempDir() + "/expand.env"
+	Go(T).AssertNil(os.WriteFile(file, []byte("PORT=8080\nADDR=${HOST}:${PORT}\n"), 0o644))
+
+	// Load keeps set values, which references resolve to
+	src := MapSource{"HOST": "localhost", "PORT": "3000"}
+	Go(T).AssertNil(New(src).Load(file))
+	Go(T).AssertEqual(src["ADDR"], "localhost:3000")
+
+	src = MapSource{"HOST": "localhost", "PORT": "3000"}
+	Go(T).AssertNil(New(src).Overload(file))
+	Go(T).AssertEqual(src["ADDR"], "localhost:8080")
+
+	// previously loaded files are referenced
+	other := T.TempDir() + "/other.env"
+	Go(T).AssertNil(os.WriteFile(other, []byte("HOST=example.com\n"), 0o644))
+
+	src = MapSource{}
+	Go(T).AssertNil(New(src).Load(other, file))
+	Go(T).AssertEqual(src["ADDR"], "example.com:8080")
+
+	s, err := NewDotenvFileSource(other, file)
+	Go(T).AssertNil(err)
+	Go(T).AssertEqual(New(s).Get("ADDR"), "example.com:8080")
+
+	// later definitions aren't referenced, even when overloading
+	src = MapSource{"B": "env"}
+	Go(T).AssertNil(os.WriteFile(file, []byte("A=$B\nB=file\n"), 0o644))
+	Go(T).AssertNil(New(src).Overload(file))
+	Go(T).AssertEqual(src["A"], "env")
+
+	Go(T).AssertNil(os.WriteFile(file, []byte("PORT=8080\nADDR=${HOST}:${PORT}\n"), 0o644))
+
+	e := New(MapSource{})
+	e.DisableExpansion = true
+	Go(T).AssertNil(e.Load(file))
+	Go(T).AssertEqual(e.Get("ADDR"), "${HOST}:${PORT}")
+}
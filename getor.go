@@ -0,0 +1,111 @@
+package env
+
+import "time"
+
+// IsSet reports whether key counts as set, honoring AllowEmpty, i.e. whether
+// GetOr- and GetOrSet- methods return its value rather than the default
+func (e *Env) IsSet(key string) bool {
+	_, ok := e.present(key, "")
+	return ok
+}
+
+// GetOr gets a key and returns a string or the default, without setting it
+func (e *Env) GetOr(key string, val interface{}) string {
+	if str, ok := e.present(key, "string"); ok {
+		return str
+	}
+	e.useDefault(key, val)
+	return e.toString(val)
+}
+
+// GetOrString is an alias to GetOr, except it only takes a string as
+// default value
+func (e *Env) GetOrString(key, val string) string {
+	return e.GetOr(key, val)
+}
+
+// GetOrBytes gets key and returns value as []byte or the default
+func (e *Env) GetOrBytes(key string, val []byte) []byte {
+	if str, ok := e.present(key, "bytes"); ok {
+		return []byte(str)
+	}
+	e.useDefault(key, val)
+	return val
+}
+
+// GetOrDuration gets key and returns value as time.Duration or the default
+func (e *Env) GetOrDuration(key string, val time.Duration) time.Duration {
+	if str, ok := e.present(key, "duration"); ok {
+		d, err := parseDur(e.name(key), str)
+		e.strict(err)
+		return d
+	}
+	e.useDefault(key, val)
+	return val
+}
+
+// GetOrInt gets key and returns value as int or the default
+func (e *Env) GetOrInt(key string, val int) int {
+	if str, ok := e.present(key, "int"); ok {
+		i, err := parseInt(e.name(key), str)
+		e.strict(err)
+		return i
+	}
+	e.useDefault(key, val)
+	return val
+}
+
+// GetOrInt32 gets key and returns value as int32 or the default
+func (e *Env) GetOrInt32(key string, val int32) int32 {
+	if str, ok := e.present(key, "int32"); ok {
+		i, err := parseInt32(e.name(key), str)
+		e.strict(err)
+		return i
+	}
+	e.useDefault(key, val)
+	return val
+}
+
+// GetOrInt64 gets key and returns value as int64 or the default
+func (e *Env) GetOrInt64(key string, val int64) int64 {
+	if str, ok := e.present(key, "int64"); ok {
+		i, err := parseInt64(e.name(key), str)
+		e.strict(err)
+		return i
+	}
+	e.useDefault(key, val)
+	return val
+}
+
+// GetOrFloat32 gets key and returns value as float32 or the default
+func (e *Env) GetOrFloat32(key string, val float32) float32 {
+	if str, ok := e.present(key, "float32"); ok {
+		f, err := parseFloat32(e.name(key), str)
+		e.strict(err)
+		return f
+	}
+	e.useDefault(key, val)
+	return val
+}
+
+// GetOrFloat64 gets key and returns value as float64 or the default
+func (e *Env) GetOrFloat64(key string, val float64) float64 {
+	if str, ok := e.present(key, "float64"); ok {
+		f, err := parseFloat64(e.name(key), str)
+		e.strict(err)
+		return f
+	}
+	e.useDefault(key, val)
+	return val
+}
+
+// GetOrBool gets key and returns value as bool or the default
+func (e *Env) GetOrBool(key string, val bool) bool {
+	if str, ok := e.present(key, "bool"); ok {
+		b, err := parseBool(e.name(key), str)
+		e.strict(err)
+		return b
+	}
+	e.useDefault(key, val)
+	return val
+}
@@ -0,0 +1,101 @@
+package env
+
+import (
+	. "github.com/jmervine/env/_fixtures"
+
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+func TestGetOr(T *testing.T) {
+	defer UnsetFixtures()
+
+	Go(T).Refute(IsSet("F_STRING"))
+	Go(T).AssertEqual(GetOr("F_STRING", "default"), "default")
+	Go(T).AssertEqual(GetOr("F_INT", 1), "1")
+	Go(T).AssertEqual(GetOrString("F_STRING", "default"), "default")
+
+	// defaults aren't written to the environment
+	_, ok := Lookup("F_STRING")
+	Go(T).Refute(ok)
+	_, ok = Lookup("F_INT")
+	Go(T).Refute(ok)
+
+	SetFixtures()
+
+	Go(T).Assert(IsSet("F_STRING"))
+	Go(T).AssertEqual(GetOr("F_STRING", "default"), "string")
+	Go(T).AssertEqual(GetOrString("F_STRING", "default"), "string")
+}
+
+func TestGetOr_typed(T *testing.T) {
+	defer UnsetFixtures()
+
+	Go(T).AssertEqual(GetOrBytes("F_BYTES", []byte("default")), []byte("default"))
+	Go(T).AssertEqual(GetOrDuration("F_DURATION", time.Minute), time.Minute)
+	Go(T).AssertEqual(GetOrInt("F_INT", 2), 2)
+	Go(T).AssertEqual(GetOrInt32("F_INT32", 2), int32(2))
+	Go(T).AssertEqual(GetOrInt64("F_INT64", 2), int64(2))
+	Go(T).AssertEqual(GetOrFloat32("F_FLOAT32", 2), float32(2))
+	Go(T).AssertEqual(GetOrFloat64("F_FLOAT64", 2), float64(2))
+	Go(T).Assert(GetOrBool("F_BOOL", true))
+
+	for key := range Fixtures {
+		_, ok := Lookup(key)
+		Go(T).Refute(ok, key)
+	}
+
+	SetFixtures()
+
+	Go(T).AssertEqual(GetOrBytes("F_BYTES", nil), Fixtures["F_BYTES"])
+	Go(T).AssertEqual(GetOrDuration("F_DURATION", time.Minute), Fixtures["F_DURATION"])
+	Go(T).AssertEqual(GetOrInt("F_INT", 2), Fixtures["F_INT"])
+	Go(T).AssertEqual(GetOrInt32("F_INT32", 2), Fixtures["F_INT32"])
+	Go(T).AssertEqual(GetOrInt64("F_INT64", 2), Fixtures["F_INT64"])
+	Go(T).AssertEqual(GetOrFloat32("F_FLOAT32", 2), Fixtures["F_FLOAT32"])
+	Go(T).AssertEqual(GetOrFloat64("F_FLOAT64", 2), Fixtures["F_FLOAT64"])
+	Go(T).AssertEqual(GetOrBool("F_BOOL", false), Fixtures["F_BOOL"])
+}
+
+func TestGetOr_lists(T *testing.T) {
+	defer os.Unsetenv("G_LIST")
+
+	Go(T).AssertDeepEqual(GetOrStrings("G_LIST", []string{"a"}), []string{"a"})
+	Go(T).AssertDeepEqual(GetOrInts("G_LIST", []int{1}), []int{1})
+	Go(T).AssertDeepEqual(GetOrFloats("G_LIST", []float64{1}), []float64{1})
+	Go(T).AssertDeepEqual(GetOrDurations("G_LIST", []time.Duration{1}), []time.Duration{1})
+	Go(T).AssertDeepEqual(GetOrBools("G_LIST", []bool{true}), []bool{true})
+	Go(T).Refute(IsSet("G_LIST"))
+
+	Set("G_LIST", "1,0")
+	Go(T).AssertDeepEqual(GetOrStrings("G_LIST", nil), []string{"1", "0"})
+	Go(T).AssertDeepEqual(GetOrInts("G_LIST", nil), []int{1, 0})
+	Go(T).AssertDeepEqual(GetOrFloats("G_LIST", nil), []float64{1, 0})
+	Go(T).AssertDeepEqual(GetOrBools("G_LIST", nil), []bool{true, false})
+
+	Set("G_LIST", "1s")
+	Go(T).AssertDeepEqual(GetOrDurations("G_LIST", nil), []time.Duration{time.Second})
+}
+
+func TestGetOr_maps(T *testing.T) {
+	defer os.Unsetenv("G_MAP")
+
+	Go(T).AssertDeepEqual(GetOrMap("G_MAP", map[string]string{"a": "b"}), map[string]string{"a": "b"})
+	Go(T).AssertDeepEqual(GetOrIntMap("G_MAP", map[string]int{"a": 1}), map[string]int{"a": 1})
+	Go(T).AssertDeepEqual(GetOrFloatMap("G_MAP", map[string]float64{"a": 1}), map[string]float64{"a": 1})
+	Go(T).AssertDeepEqual(GetOrDurationMap("G_MAP", map[string]time.Duration{"a": 1}), map[string]time.Duration{"a": 1})
+	Go(T).AssertDeepEqual(GetOrBoolMap("G_MAP", map[string]bool{"a": true}), map[string]bool{"a": true})
+	Go(T).Refute(IsSet("G_MAP"))
+
+	Set("G_MAP", "a=1")
+	Go(T).AssertDeepEqual(GetOrMap("G_MAP", nil), map[string]string{"a": "1"})
+	Go(T).AssertDeepEqual(GetOrIntMap("G_MAP", nil), map[string]int{"a": 1})
+	Go(T).AssertDeepEqual(GetOrFloatMap("G_MAP", nil), map[string]float64{"a": 1})
+	Go(T).AssertDeepEqual(GetOrBoolMap("G_MAP", nil), map[string]bool{"a": true})
+
+	Set("G_MAP", "a=1s")
+	Go(T).AssertDeepEqual(GetOrDurationMap("G_MAP", nil), map[string]time.Duration{"a": time.Second})
+}
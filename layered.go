@@ -0,0 +1,121 @@
+package env
+
+import (
+	"errors"
+	"sort"
+)
+
+// Precedence decides which layer of a LayeredSource wins when a key is set
+// in more than one
+type Precedence int
+
+const (
+	// LastWins gives later layers precedence, so layers are listed from
+	// lowest to highest precedence, e.g. defaults first and overrides last
+	LastWins Precedence = iota
+
+	// FirstWins gives earlier layers precedence, like Load does across
+	// files
+	FirstWins
+)
+
+// LayeredSource resolves keys across ordered layers of Sources
+type LayeredSource struct {
+	layers     []Source
+	precedence Precedence
+}
+
+// Layered returns a LayeredSource over sources, where later sources take
+// precedence
+//
+// e.g.:
+//
+//	defaults := env.MapSource{"PORT": "3000"}
+//	file, _ := env.NewDotenvFileSource(".env")
+//	local, _ := env.NewDotenvFileSource(".env.local")
+//	overrides := env.MapSource{}
+//
+//	e := env.New(env.Layered(defaults, file, local, env.OSSource{}, overrides))
+func Layered(sources ...Source) *LayeredSource {
+	return &LayeredSource{layers: sources, precedence: LastWins}
+}
+
+// WithPrecedence sets the precedence used to pick the winning layer,
+// returning s
+func (s *LayeredSource) WithPrecedence(p Precedence) *LayeredSource {
+	s.precedence = p
+	return s
+}
+
+// Layers returns the layers from highest to lowest precedence
+func (s *LayeredSource) Layers() []Source {
+	layers := make([]Source, len(s.layers))
+	for i, layer := range s.layers {
+		if s.precedence == LastWins {
+			layers[len(layers)-1-i] = layer
+		} else {
+			layers[i] = layer
+		}
+	}
+
+	return layers
+}
+
+// Lookup returns key's value from the winning layer
+func (s *LayeredSource) Lookup(key string) (string, bool) {
+	for _, layer := range s.Layers() {
+		if val, ok := layer.Lookup(key); ok {
+			return val, true
+		}
+	}
+
+	return "", false
+}
+
+// Keys returns the sorted union of the keys in every layer
+func (s *LayeredSource) Keys() []string {
+	seen := make(map[string]bool)
+	keys := make([]string, 0)
+
+	for _, layer := range s.layers {
+		for _, key := range layer.Keys() {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Explain returns the candidates for key in every layer, highest precedence
+// first
+func (s *LayeredSource) Explain(key string) []Origin {
+	origins := make([]Origin, 0)
+	for _, layer := range s.Layers() {
+		origins = append(origins, explain(layer, key)...)
+	}
+
+	return origins
+}
+
+// Set sets key in the highest precedence layer which is writable, so the
+// value wins, returning ErrReadOnly if none are. Layers which implement
+// Setter but return ErrReadOnly, such as a PrefixSource wrapping a State,
+// are skipped.
+func (s *LayeredSource) Set(key, val string) error {
+	for _, layer := range s.Layers() {
+		setter, ok := layer.(Setter)
+		if !ok {
+			continue
+		}
+
+		if err := setter.Set(key, val); !errors.Is(err, ErrReadOnly) {
+			return err
+		}
+	}
+
+	return ErrReadOnly
+}
@@ -0,0 +1,75 @@
+package env
+
+import (
+	"testing"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+func TestLayered(T *testing.T) {
+	T.Parallel()
+
+	defaults := MapSource{"PORT": "3000", "ADDR": "0.0.0.0", "DEBUG": "false"}
+	file, err := NewDotenvFileSource(env)
+	Go(T).AssertNil(err)
+	overrides := MapSource{"DEBUG": "true", "F_INT": "1"}
+
+	src := Layered(defaults, file, overrides)
+	e := New(src)
+
+	Go(T).AssertEqual(e.GetInt("PORT"), 3000)
+	Go(T).AssertEqual(e.GetInt("F_INT"), 1)
+	Go(T).AssertEqual(e.GetInt("F_INT32"), 9)
+	Go(T).Assert(e.GetBool("DEBUG"))
+
+	_, ok := src.Lookup("MISSING")
+	Go(T).Refute(ok)
+
+	keys := src.Keys()
+	Go(T).AssertContains(keys, "PORT")
+	Go(T).AssertContains(keys, "F_INT32")
+	Go(T).AssertEqual(len(keys), len(file.Keys())+3)
+
+	Go(T).AssertDeepEqual(src.Layers(), []Source{overrides, file, defaults})
+
+	// writes go to the winning layer
+	e.Set("PORT", 8080)
+	Go(T).AssertEqual(overrides["PORT"], "8080")
+	Go(T).AssertEqual(defaults["PORT"], "3000")
+	Go(T).AssertEqual(e.GetInt("PORT"), 8080)
+}
+
+func TestLayered_firstWins(T *testing.T) {
+	T.Parallel()
+
+	first := MapSource{"PORT": "3000"}
+	file, err := NewDotenvFileSource(env)
+	Go(T).AssertNil(err)
+	last := MapSource{"PORT": "8080", "F_INT": "1"}
+
+	src := Layered(first, file, last).WithPrecedence(FirstWins)
+	e := New(src)
+
+	Go(T).AssertEqual(e.GetInt("PORT"), 3000)
+	Go(T).AssertEqual(e.GetInt("F_INT"), 9)
+	Go(T).AssertDeepEqual(src.Layers(), []Source{first, file, last})
+
+	e.Set("ADDR", "0.0.0.0")
+	Go(T).AssertEqual(first["ADDR"], "0.0.0.0")
+
+	Go(T).AssertEqual(Layered(file).Set("ADDR", "0.0.0.0"), ErrReadOnly)
+}
+
+func TestLayered_setSkipsReadOnly(T *testing.T) {
+	T.Parallel()
+
+	// a prefixed State implements Setter, but can't be written to
+	m := MapSource{}
+	state := New(MapSource{"X_PORT": "3000"}).Snapshot()
+	src := Layered(m, Prefixed("X_", state))
+
+	Go(T).AssertNil(src.Set("ADDR", "0.0.0.0"))
+	Go(T).AssertEqual(m["ADDR"], "0.0.0.0")
+
+	Go(T).AssertEqual(Layered(Prefixed("X_", state)).Set("ADDR", "0.0.0.0"), ErrReadOnly)
+}
@@ -0,0 +1,394 @@
+package env
+
+import (
+	"strings"
+	"time"
+)
+
+// ListSeparator separates elements of list values read by the GetStrings
+// family and written by Set for slices
+var ListSeparator = ","
+
+// GetStrings gets a key and splits it on ListSeparator, trimming whitespace
+// around each element and dropping empty elements. Elements containing the
+// separator can be wrapped in double quotes
+//
+// e.g.:
+//
+//	HOSTS=a.example.com, b.example.com, "c,d"
+func (e *Env) GetStrings(key string) []string {
+	return splitList(e.get(key, "strings"), e.ListSeparator)
+}
+
+// RequireStrings requires key and returns it as a []string, validating each
+// element against rules
+func (e *Env) RequireStrings(key string, rules ...Rule) ([]string, error) {
+	return e.requireList(key, "strings", rules)
+}
+
+// GetOrSetStrings gets or sets key and returns value as []string
+func (e *Env) GetOrSetStrings(key string, val []string) []string {
+	if str, ok := e.present(key, "strings"); ok {
+		return splitList(str, e.ListSeparator)
+	}
+	e.setDefault(key, val)
+	return val
+}
+
+// GetOrStrings gets key and returns value as []string or the default,
+// without setting it
+func (e *Env) GetOrStrings(key string, val []string) []string {
+	if str, ok := e.present(key, "strings"); ok {
+		return splitList(str, e.ListSeparator)
+	}
+	e.useDefault(key, val)
+	return val
+}
+
+// GetStringSet gets a key and returns its elements as a set
+func (e *Env) GetStringSet(key string) map[string]bool {
+	set := make(map[string]bool)
+	for _, s := range splitList(e.get(key, "strings"), e.ListSeparator) {
+		set[s] = true
+	}
+	return set
+}
+
+// GetUniqueStrings gets a key and returns its elements with duplicates
+// removed, preserving their order
+func (e *Env) GetUniqueStrings(key string) []string {
+	seen := make(map[string]bool)
+	strs := make([]string, 0)
+	for _, s := range splitList(e.get(key, "strings"), e.ListSeparator) {
+		if !seen[s] {
+			seen[s] = true
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
+
+// GetInts gets a key and returns it as an []int
+func (e *Env) GetInts(key string) []int {
+	v, err := toInts(e.name(key), splitList(e.get(key, "ints"), e.ListSeparator))
+	e.strict(err)
+	return v
+}
+
+// RequireInts requires key and returns it as an []int
+func (e *Env) RequireInts(key string, rules ...Rule) ([]int, error) {
+	strs, err := e.requireList(key, "ints", rules)
+	if err != nil {
+		return nil, err
+	}
+	v, err := toInts(e.name(key), strs)
+	if err != nil {
+		return nil, e.onError(err)
+	}
+	return v, nil
+}
+
+// GetOrSetInts gets or sets key and returns value as []int
+func (e *Env) GetOrSetInts(key string, val []int) []int {
+	if str, ok := e.present(key, "ints"); ok {
+		v, err := toInts(e.name(key), splitList(str, e.ListSeparator))
+		e.strict(err)
+		return v
+	}
+	e.setDefault(key, val)
+	return val
+}
+
+// GetOrInts gets key and returns value as []int or the default
+func (e *Env) GetOrInts(key string, val []int) []int {
+	if str, ok := e.present(key, "ints"); ok {
+		v, err := toInts(e.name(key), splitList(str, e.ListSeparator))
+		e.strict(err)
+		return v
+	}
+	e.useDefault(key, val)
+	return val
+}
+
+// GetFloats gets a key and returns it as a []float64
+func (e *Env) GetFloats(key string) []float64 {
+	v, err := toFloats(e.name(key), splitList(e.get(key, "floats"), e.ListSeparator))
+	e.strict(err)
+	return v
+}
+
+// RequireFloats requires key and returns it as a []float64
+func (e *Env) RequireFloats(key string, rules ...Rule) ([]float64, error) {
+	strs, err := e.requireList(key, "floats", rules)
+	if err != nil {
+		return nil, err
+	}
+	v, err := toFloats(e.name(key), strs)
+	if err != nil {
+		return nil, e.onError(err)
+	}
+	return v, nil
+}
+
+// GetOrSetFloats gets or sets key and returns value as []float64
+func (e *Env) GetOrSetFloats(key string, val []float64) []float64 {
+	if str, ok := e.present(key, "floats"); ok {
+		v, err := toFloats(e.name(key), splitList(str, e.ListSeparator))
+		e.strict(err)
+		return v
+	}
+	e.setDefault(key, val)
+	return val
+}
+
+// GetOrFloats gets key and returns value as []float64 or the default
+func (e *Env) GetOrFloats(key string, val []float64) []float64 {
+	if str, ok := e.present(key, "floats"); ok {
+		v, err := toFloats(e.name(key), splitList(str, e.ListSeparator))
+		e.strict(err)
+		return v
+	}
+	e.useDefault(key, val)
+	return val
+}
+
+// GetDurations gets a key and returns it as a []time.Duration
+func (e *Env) GetDurations(key string) []time.Duration {
+	v, err := toDurs(e.name(key), splitList(e.get(key, "durations"), e.ListSeparator))
+	e.strict(err)
+	return v
+}
+
+// RequireDurations requires key and returns it as a []time.Duration
+func (e *Env) RequireDurations(key string, rules ...Rule) ([]time.Duration, error) {
+	strs, err := e.requireList(key, "durations", rules)
+	if err != nil {
+		return nil, err
+	}
+	v, err := toDurs(e.name(key), strs)
+	if err != nil {
+		return nil, e.onError(err)
+	}
+	return v, nil
+}
+
+// GetOrSetDurations gets or sets key and returns value as []time.Duration
+func (e *Env) GetOrSetDurations(key string, val []time.Duration) []time.Duration {
+	if str, ok := e.present(key, "durations"); ok {
+		v, err := toDurs(e.name(key), splitList(str, e.ListSeparator))
+		e.strict(err)
+		return v
+	}
+	e.setDefault(key, val)
+	return val
+}
+
+// GetOrDurations gets key and returns value as []time.Duration or the default
+func (e *Env) GetOrDurations(key string, val []time.Duration) []time.Duration {
+	if str, ok := e.present(key, "durations"); ok {
+		v, err := toDurs(e.name(key), splitList(str, e.ListSeparator))
+		e.strict(err)
+		return v
+	}
+	e.useDefault(key, val)
+	return val
+}
+
+// GetBools gets a key and returns it as a []bool
+func (e *Env) GetBools(key string) []bool {
+	v, err := toBools(e.name(key), splitList(e.get(key, "bools"), e.ListSeparator))
+	e.strict(err)
+	return v
+}
+
+// RequireBools requires key and returns it as a []bool
+func (e *Env) RequireBools(key string, rules ...Rule) ([]bool, error) {
+	strs, err := e.requireList(key, "bools", rules)
+	if err != nil {
+		return nil, err
+	}
+	v, err := toBools(e.name(key), strs)
+	if err != nil {
+		return nil, e.onError(err)
+	}
+	return v, nil
+}
+
+// GetOrSetBools gets or sets key and returns value as []bool
+func (e *Env) GetOrSetBools(key string, val []bool) []bool {
+	if str, ok := e.present(key, "bools"); ok {
+		v, err := toBools(e.name(key), splitList(str, e.ListSeparator))
+		e.strict(err)
+		return v
+	}
+	e.setDefault(key, val)
+	return val
+}
+
+// GetOrBools gets key and returns value as []bool or the default
+func (e *Env) GetOrBools(key string, val []bool) []bool {
+	if str, ok := e.present(key, "bools"); ok {
+		v, err := toBools(e.name(key), splitList(str, e.ListSeparator))
+		e.strict(err)
+		return v
+	}
+	e.useDefault(key, val)
+	return val
+}
+
+// HELPERS
+func (e *Env) requireList(key, typ string, rules []Rule) ([]string, error) {
+	str, err := e.require(key, typ, presence(rules))
+	if err != nil {
+		return nil, err
+	}
+
+	strs := splitList(str, e.ListSeparator)
+	for _, s := range strs {
+		if err := validate(e.name(key), typ, s, rules); err != nil {
+			return nil, e.onError(err)
+		}
+	}
+
+	return strs, nil
+}
+
+// presence returns the rules which affect whether a list or map is present,
+// as the others apply to its elements
+func presence(rules []Rule) []Rule {
+	if acceptsEmpty(rules) {
+		return []Rule{AcceptEmpty}
+	}
+	return nil
+}
+
+// splitList splits val on sep, honoring double quoted elements and
+// backslash escapes within them
+func splitList(val, sep string) []string {
+	sep = separator(sep, ",")
+
+	strs := make([]string, 0)
+	if strings.TrimSpace(val) == "" {
+		return strs
+	}
+
+	var (
+		b      strings.Builder
+		quoted bool // current element was quoted, keep it even if empty
+		inside bool // currently inside quotes
+	)
+
+	flush := func() {
+		s := b.String()
+		if !quoted {
+			s = strings.TrimSpace(s)
+		}
+		if s != "" || quoted {
+			strs = append(strs, s)
+		}
+		b.Reset()
+		quoted = false
+	}
+
+	for i := 0; i < len(val); i++ {
+		c := val[i]
+
+		switch {
+		case inside && c == '\\' && i+1 < len(val):
+			i++
+			b.WriteByte(val[i])
+		case inside && c == '"':
+			inside = false
+		case !inside && c == '"' && strings.TrimSpace(b.String()) == "":
+			b.Reset()
+			inside, quoted = true, true
+		case !inside && strings.HasPrefix(val[i:], sep):
+			flush()
+			i += len(sep) - 1
+		case quoted && !inside:
+			// drop anything trailing a closing quote other than whitespace
+			if c != ' ' && c != '\t' {
+				b.WriteByte(c)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	flush()
+
+	return strs
+}
+
+// joinList joins strs with sep, quoting elements which contain the
+// separator or quotes, have leading or trailing whitespace, or are empty
+func joinList(strs []string, sep string) string {
+	sep = separator(sep, ",")
+
+	quoted := make([]string, 0, len(strs))
+	for _, s := range strs {
+		if s == "" || strings.Contains(s, sep) || strings.Contains(s, "\"") || s != strings.TrimSpace(s) {
+			s = strings.Replace(s, "\\", "\\\\", -1)
+			s = strings.Replace(s, "\"", "\\\"", -1)
+			s = "\"" + s + "\""
+		}
+		quoted = append(quoted, s)
+	}
+	return strings.Join(quoted, sep)
+}
+
+// separator returns sep, or def if sep is empty, as an empty separator
+// would match everywhere
+func separator(sep, def string) string {
+	if sep == "" {
+		return def
+	}
+	return sep
+}
+
+func toInts(key string, strs []string) ([]int, error) {
+	ints := make([]int, 0, len(strs))
+	for _, s := range strs {
+		i, err := parseInt(key, s)
+		if err != nil {
+			return nil, err
+		}
+		ints = append(ints, i)
+	}
+	return ints, nil
+}
+
+func toFloats(key string, strs []string) ([]float64, error) {
+	floats := make([]float64, 0, len(strs))
+	for _, s := range strs {
+		f, err := parseFloat64(key, s)
+		if err != nil {
+			return nil, err
+		}
+		floats = append(floats, f)
+	}
+	return floats, nil
+}
+
+func toDurs(key string, strs []string) ([]time.Duration, error) {
+	durs := make([]time.Duration, 0, len(strs))
+	for _, s := range strs {
+		d, err := parseDur(key, s)
+		if err != nil {
+			return nil, err
+		}
+		durs = append(durs, d)
+	}
+	return durs, nil
+}
+
+func toBools(key string, strs []string) ([]bool, error) {
+	bools := make([]bool, 0, len(strs))
+	for _, s := range strs {
+		b, err := parseBool(key, s)
+		if err != nil {
+			return nil, err
+		}
+		bools = append(bools, b)
+	}
+	return bools, nil
+}
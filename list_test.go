@@ -0,0 +1,123 @@
+package env
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+func TestGetStrings(T *testing.T) {
+	defer os.Unsetenv("L_STRINGS")
+
+	Go(T).AssertLength(GetStrings("L_STRINGS"), 0)
+
+	Set("L_STRINGS", " a, b ,,\"c,d\", \" e \",\"f\\\"g\"")
+	Go(T).AssertDeepEqual(GetStrings("L_STRINGS"), []string{"a", "b", "c,d", " e ", "f\"g"})
+}
+
+func TestRequireStrings(T *testing.T) {
+	defer os.Unsetenv("L_STRINGS")
+
+	s, e := RequireStrings("L_STRINGS")
+	Go(T).AssertEqual(e.Error(), "missing required strings from L_STRINGS")
+	Go(T).AssertNil(s)
+
+	Set("L_STRINGS", "debug,info")
+	s, e = RequireStrings("L_STRINGS", OneOf("debug", "info"))
+	Go(T).AssertNil(e)
+	Go(T).AssertDeepEqual(s, []string{"debug", "info"})
+
+	Set("L_STRINGS", "debug,verbose")
+	_, e = RequireStrings("L_STRINGS", OneOf("debug", "info"))
+	Go(T).AssertEqual(e.Error(), "invalid strings from L_STRINGS: \"verbose\" does not satisfy oneof=debug info")
+}
+
+func TestGetOrSetStrings(T *testing.T) {
+	defer os.Unsetenv("L_STRINGS")
+
+	def := []string{"a", "b,c"}
+	Go(T).AssertDeepEqual(GetOrSetStrings("L_STRINGS", def), def)
+	Go(T).AssertEqual(Get("L_STRINGS"), "a,\"b,c\"")
+	Go(T).AssertDeepEqual(GetOrSetStrings("L_STRINGS", nil), def)
+}
+
+func TestGetStringSet(T *testing.T) {
+	defer os.Unsetenv("L_STRINGS")
+	Set("L_STRINGS", "a,b,a,c,b")
+
+	Go(T).AssertDeepEqual(GetStringSet("L_STRINGS"), map[string]bool{"a": true, "b": true, "c": true})
+	Go(T).AssertDeepEqual(GetUniqueStrings("L_STRINGS"), []string{"a", "b", "c"})
+}
+
+func TestGetInts(T *testing.T) {
+	defer os.Unsetenv("L_INTS")
+
+	i := GetOrSetInts("L_INTS", []int{1, 2, 3})
+	Go(T).AssertDeepEqual(i, []int{1, 2, 3})
+	Go(T).AssertEqual(Get("L_INTS"), "1,2,3")
+	Go(T).AssertDeepEqual(GetInts("L_INTS"), []int{1, 2, 3})
+
+	i, e := RequireInts("L_INTS", Max(2))
+	Go(T).RefuteNil(e)
+	Go(T).AssertNil(i)
+}
+
+func TestGetFloats(T *testing.T) {
+	defer os.Unsetenv("L_FLOATS")
+
+	f, e := RequireFloats("L_FLOATS")
+	Go(T).RefuteNil(e)
+
+	f = GetOrSetFloats("L_FLOATS", []float64{1.5, 2})
+	Go(T).AssertDeepEqual(GetFloats("L_FLOATS"), f)
+}
+
+func TestGetDurations(T *testing.T) {
+	defer os.Unsetenv("L_DURATIONS")
+
+	d := GetOrSetDurations("L_DURATIONS", []time.Duration{time.Second, time.Minute})
+	Go(T).AssertEqual(Get("L_DURATIONS"), "1s,1m0s")
+	Go(T).AssertDeepEqual(GetDurations("L_DURATIONS"), d)
+
+	r, e := RequireDurations("L_DURATIONS")
+	Go(T).AssertNil(e)
+	Go(T).AssertDeepEqual(r, d)
+}
+
+func TestGetBools(T *testing.T) {
+	defer os.Unsetenv("L_BOOLS")
+
+	b := GetOrSetBools("L_BOOLS", []bool{true, false})
+	Go(T).AssertDeepEqual(GetBools("L_BOOLS"), b)
+
+	r, e := RequireBools("L_BOOLS")
+	Go(T).AssertNil(e)
+	Go(T).AssertDeepEqual(r, b)
+}
+
+func TestListSeparator(T *testing.T) {
+	defer os.Unsetenv("L_STRINGS")
+	defer func() { ListSeparator = "," }()
+
+	ListSeparator = ":"
+	Set("L_STRINGS", []string{"/bin", "/usr/bin", "c:d"})
+	Go(T).AssertEqual(Get("L_STRINGS"), "/bin:/usr/bin:\"c:d\"")
+	Go(T).AssertDeepEqual(GetStrings("L_STRINGS"), []string{"/bin", "/usr/bin", "c:d"})
+}
+
+func TestListSeparator_empty(T *testing.T) {
+	defer os.Unsetenv("L_STRINGS")
+	defer func() { ListSeparator = "," }()
+
+	ListSeparator = ""
+	Set("L_STRINGS", []string{"a", "b"})
+	Go(T).AssertEqual(Get("L_STRINGS"), "a,b")
+	Go(T).AssertDeepEqual(GetStrings("L_STRINGS"), []string{"a", "b"})
+}
+
+func Test_joinList(T *testing.T) {
+	strs := []string{"a", "b,c", " d", "e\"f", ""}
+	Go(T).AssertDeepEqual(splitList(joinList(strs, ","), ","), strs)
+}
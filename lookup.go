@@ -0,0 +1,104 @@
+package env
+
+import "time"
+
+// AllowEmpty makes Require- and GetOrSet- methods treat keys set to an empty
+// value as present, only treating unset keys as missing. Pass AcceptEmpty to
+// do the same for a single Require- call.
+var AllowEmpty = false
+
+// Lookup gets a key and returns its value and whether it's set, even if
+// it's set to an empty value
+func (e *Env) Lookup(key string) (string, bool) {
+	return e.lookup(key, "string")
+}
+
+// LookupBytes looks up key and returns value as []byte
+func (e *Env) LookupBytes(key string) ([]byte, bool) {
+	str, ok := e.lookup(key, "bytes")
+	return []byte(str), ok
+}
+
+// LookupDuration looks up key and returns value as time.Duration
+func (e *Env) LookupDuration(key string) (time.Duration, bool) {
+	str, ok := e.lookup(key, "duration")
+	if !ok {
+		return time.Duration(0), false
+	}
+
+	d, err := parseDur(e.name(key), str)
+	e.strict(err)
+	return d, true
+}
+
+// LookupInt looks up key and returns value as int
+func (e *Env) LookupInt(key string) (int, bool) {
+	str, ok := e.lookup(key, "int")
+	if !ok {
+		return int(0), false
+	}
+
+	i, err := parseInt(e.name(key), str)
+	e.strict(err)
+	return i, true
+}
+
+// LookupInt32 looks up key and returns value as int32
+func (e *Env) LookupInt32(key string) (int32, bool) {
+	str, ok := e.lookup(key, "int32")
+	if !ok {
+		return int32(0), false
+	}
+
+	i, err := parseInt32(e.name(key), str)
+	e.strict(err)
+	return i, true
+}
+
+// LookupInt64 looks up key and returns value as int64
+func (e *Env) LookupInt64(key string) (int64, bool) {
+	str, ok := e.lookup(key, "int64")
+	if !ok {
+		return int64(0), false
+	}
+
+	i, err := parseInt64(e.name(key), str)
+	e.strict(err)
+	return i, true
+}
+
+// LookupFloat32 looks up key and returns value as float32
+func (e *Env) LookupFloat32(key string) (float32, bool) {
+	str, ok := e.lookup(key, "float32")
+	if !ok {
+		return float32(0), false
+	}
+
+	f, err := parseFloat32(e.name(key), str)
+	e.strict(err)
+	return f, true
+}
+
+// LookupFloat64 looks up key and returns value as float64
+func (e *Env) LookupFloat64(key string) (float64, bool) {
+	str, ok := e.lookup(key, "float64")
+	if !ok {
+		return float64(0), false
+	}
+
+	f, err := parseFloat64(e.name(key), str)
+	e.strict(err)
+	return f, true
+}
+
+// LookupBool looks up key and returns value as bool
+func (e *Env) LookupBool(key string) (bool, bool) {
+	str, ok := e.lookup(key, "bool")
+	if !ok {
+		return false, false
+	}
+
+	b, err := parseBool(e.name(key), str)
+	e.strict(err)
+	return b, true
+}
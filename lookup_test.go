@@ -0,0 +1,187 @@
+package env
+
+import (
+	. "github.com/jmervine/env/_fixtures"
+
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+func TestLookup(T *testing.T) {
+	defer UnsetFixtures()
+
+	s, ok := Lookup("F_STRING")
+	Go(T).Refute(ok)
+	Go(T).AssertEqual(s, "")
+
+	os.Setenv("F_STRING", "")
+	s, ok = Lookup("F_STRING")
+	Go(T).Assert(ok)
+	Go(T).AssertEqual(s, "")
+
+	SetFixtures()
+	s, ok = Lookup("F_STRING")
+	Go(T).Assert(ok)
+	Go(T).AssertEqual(s, "string")
+}
+
+func TestLookup_typed(T *testing.T) {
+	defer UnsetFixtures()
+
+	_, ok := LookupInt("F_INT")
+	Go(T).Refute(ok)
+	_, ok = LookupBytes("F_BYTES")
+	Go(T).Refute(ok)
+	_, ok = LookupDuration("F_DURATION")
+	Go(T).Refute(ok)
+	_, ok = LookupInt32("F_INT32")
+	Go(T).Refute(ok)
+	_, ok = LookupInt64("F_INT64")
+	Go(T).Refute(ok)
+	_, ok = LookupFloat32("F_FLOAT32")
+	Go(T).Refute(ok)
+	_, ok = LookupFloat64("F_FLOAT64")
+	Go(T).Refute(ok)
+	_, ok = LookupBool("F_BOOL")
+	Go(T).Refute(ok)
+
+	SetFixtures()
+
+	i, ok := LookupInt("F_INT")
+	Go(T).Assert(ok)
+	Go(T).AssertEqual(i, Fixtures["F_INT"])
+
+	b, ok := LookupBytes("F_BYTES")
+	Go(T).Assert(ok)
+	Go(T).AssertEqual(b, Fixtures["F_BYTES"])
+
+	d, ok := LookupDuration("F_DURATION")
+	Go(T).Assert(ok)
+	Go(T).AssertEqual(d, time.Hour)
+
+	i32, _ := LookupInt32("F_INT32")
+	Go(T).AssertEqual(i32, Fixtures["F_INT32"])
+
+	i64, _ := LookupInt64("F_INT64")
+	Go(T).AssertEqual(i64, Fixtures["F_INT64"])
+
+	f32, _ := LookupFloat32("F_FLOAT32")
+	Go(T).AssertEqual(f32, Fixtures["F_FLOAT32"])
+
+	f64, _ := LookupFloat64("F_FLOAT64")
+	Go(T).AssertEqual(f64, Fixtures["F_FLOAT64"])
+
+	t, _ := LookupBool("F_BOOL")
+	Go(T).AssertEqual(t, Fixtures["F_BOOL"])
+}
+
+func TestAllowEmpty(T *testing.T) {
+	defer UnsetFixtures()
+	os.Setenv("F_STRING", "")
+
+	// empty values are missing by default
+	_, e := Require("F_STRING")
+	Go(T).RefuteNil(e)
+	Go(T).AssertEqual(GetOrSet("F_STRING", "default"), "default")
+
+	AllowEmpty = true
+	defer func() { AllowEmpty = false }()
+
+	os.Setenv("F_STRING", "")
+
+	s, e := Require("F_STRING")
+	Go(T).AssertNil(e)
+	Go(T).AssertEqual(s, "")
+	Go(T).AssertEqual(GetOrSet("F_STRING", "default"), "")
+
+	// unset values are still missing
+	_, e = Require("F_INT")
+	Go(T).RefuteNil(e)
+}
+
+func TestLookup_emptyStrict(T *testing.T) {
+	T.Parallel()
+
+	e := New(MapSource{"EMPTY": ""})
+	e.Strict = true
+	e.AllowEmpty = true
+
+	// set but empty values are the zero value, and never malformed
+	i, ok := e.LookupInt("EMPTY")
+	Go(T).Assert(ok)
+	Go(T).AssertEqual(i, 0)
+
+	d, ok := e.LookupDuration("EMPTY")
+	Go(T).Assert(ok)
+	Go(T).AssertEqual(d, time.Duration(0))
+
+	i, err := e.GetIntE("EMPTY")
+	Go(T).AssertNil(err)
+	Go(T).AssertEqual(i, 0)
+
+	Go(T).AssertEqual(e.GetOrInt("EMPTY", 3), 0)
+	Go(T).AssertEqual(e.GetOrSetBool("EMPTY", true), false)
+
+	i, err = e.RequireInt("EMPTY")
+	Go(T).AssertNil(err)
+	Go(T).AssertEqual(i, 0)
+}
+
+func TestAcceptEmpty(T *testing.T) {
+	T.Parallel()
+
+	e := New(MapSource{"EMPTY": ""})
+
+	_, err := e.Require("EMPTY")
+	Go(T).RefuteNil(err)
+
+	s, err := e.Require("EMPTY", AcceptEmpty)
+	Go(T).AssertNil(err)
+	Go(T).AssertEqual(s, "")
+
+	_, err = e.RequireInts("EMPTY", Min(1))
+	Go(T).RefuteNil(err)
+
+	ints, err := e.RequireInts("EMPTY", AcceptEmpty, Min(1))
+	Go(T).AssertNil(err)
+	Go(T).AssertLength(ints, 0)
+
+	_, err = e.Require("MISSING", AcceptEmpty)
+	Go(T).AssertEqual(err.Error(), "missing required string from MISSING")
+
+	// it only applies to the call it's passed to
+	Go(T).RefuteNil(e.NewChecker().String("EMPTY", nil).Err())
+	Go(T).AssertNil(e.NewChecker().String("EMPTY", nil, AcceptEmpty).Err())
+}
+
+func TestLoad_setButEmpty(T *testing.T) {
+	defer UnsetFixtures()
+	os.Setenv("F_STRING", "")
+
+	Go(T).AssertNil(Load(env))
+
+	s, ok := Lookup("F_STRING")
+	Go(T).Assert(ok)
+	Go(T).AssertEqual(s, "")
+	Go(T).AssertEqual(Get("F_INT"), "9")
+}
+
+func TestLoad_precedence(T *testing.T) {
+	defer UnsetFixtures()
+
+	dir := T.TempDir()
+
+	file := filepath.Join(dir, "override.env")
+	Go(T).AssertNil(os.WriteFile(file, []byte("F_INT=1\nF_BOOL=true\n"), 0o644))
+
+	Go(T).AssertNil(Load(file, env))
+	Go(T).AssertEqual(Get("F_INT"), "1")
+	Go(T).AssertEqual(Get("F_BOOL"), "true")
+	Go(T).AssertEqual(Get("F_INT32"), "9")
+
+	Go(T).RefuteNil(Load(filepath.Join(dir, "missing.env")))
+}
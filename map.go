@@ -0,0 +1,421 @@
+package env
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// PairSeparator separates pairs of map values read by the GetMap family
+	// and written by Set for maps
+	PairSeparator = ","
+
+	// KeyValueSeparator separates keys from values within each pair of map
+	// values
+	KeyValueSeparator = "="
+)
+
+// GetMap gets a key and splits it into pairs on PairSeparator, and each pair
+// into a key and value on KeyValueSeparator, trimming whitespace around
+// both. Keys and values, or whole pairs, containing the separators can be
+// wrapped in double quotes
+//
+// e.g.:
+//
+//	EXTRA_HEADERS=X-A=1, X-B=2, X-C="3,4", "X-D=5,6"
+func (e *Env) GetMap(key string) map[string]string {
+	return e.splitMap(e.get(key, "map"))
+}
+
+// RequireMap requires key and returns it as a map[string]string, validating
+// each value against rules
+func (e *Env) RequireMap(key string, rules ...Rule) (map[string]string, error) {
+	return e.requireMap(key, "map", rules)
+}
+
+// GetOrSetMap gets or sets key and returns value as map[string]string
+func (e *Env) GetOrSetMap(key string, val map[string]string) map[string]string {
+	if str, ok := e.present(key, "map"); ok {
+		return e.splitMap(str)
+	}
+	e.setDefault(key, val)
+	return val
+}
+
+// GetOrMap gets key and returns value as map[string]string or the default,
+// without setting it
+func (e *Env) GetOrMap(key string, val map[string]string) map[string]string {
+	if str, ok := e.present(key, "map"); ok {
+		return e.splitMap(str)
+	}
+	e.useDefault(key, val)
+	return val
+}
+
+// GetIntMap gets a key and returns it as a map[string]int
+func (e *Env) GetIntMap(key string) map[string]int {
+	v, err := toIntMap(e.name(key), e.splitMap(e.get(key, "int map")))
+	e.strict(err)
+	return v
+}
+
+// RequireIntMap requires key and returns it as a map[string]int
+func (e *Env) RequireIntMap(key string, rules ...Rule) (map[string]int, error) {
+	m, err := e.requireMap(key, "int map", rules)
+	if err != nil {
+		return nil, err
+	}
+	v, err := toIntMap(e.name(key), m)
+	if err != nil {
+		return nil, e.onError(err)
+	}
+	return v, nil
+}
+
+// GetOrSetIntMap gets or sets key and returns value as map[string]int
+func (e *Env) GetOrSetIntMap(key string, val map[string]int) map[string]int {
+	if str, ok := e.present(key, "int map"); ok {
+		v, err := toIntMap(e.name(key), e.splitMap(str))
+		e.strict(err)
+		return v
+	}
+	e.setDefault(key, val)
+	return val
+}
+
+// GetOrIntMap gets key and returns value as map[string]int or the default
+func (e *Env) GetOrIntMap(key string, val map[string]int) map[string]int {
+	if str, ok := e.present(key, "int map"); ok {
+		v, err := toIntMap(e.name(key), e.splitMap(str))
+		e.strict(err)
+		return v
+	}
+	e.useDefault(key, val)
+	return val
+}
+
+// GetFloatMap gets a key and returns it as a map[string]float64
+func (e *Env) GetFloatMap(key string) map[string]float64 {
+	v, err := toFloatMap(e.name(key), e.splitMap(e.get(key, "float map")))
+	e.strict(err)
+	return v
+}
+
+// RequireFloatMap requires key and returns it as a map[string]float64
+func (e *Env) RequireFloatMap(key string, rules ...Rule) (map[string]float64, error) {
+	m, err := e.requireMap(key, "float map", rules)
+	if err != nil {
+		return nil, err
+	}
+	v, err := toFloatMap(e.name(key), m)
+	if err != nil {
+		return nil, e.onError(err)
+	}
+	return v, nil
+}
+
+// GetOrSetFloatMap gets or sets key and returns value as map[string]float64
+func (e *Env) GetOrSetFloatMap(key string, val map[string]float64) map[string]float64 {
+	if str, ok := e.present(key, "float map"); ok {
+		v, err := toFloatMap(e.name(key), e.splitMap(str))
+		e.strict(err)
+		return v
+	}
+	e.setDefault(key, val)
+	return val
+}
+
+// GetOrFloatMap gets key and returns value as map[string]float64 or the default
+func (e *Env) GetOrFloatMap(key string, val map[string]float64) map[string]float64 {
+	if str, ok := e.present(key, "float map"); ok {
+		v, err := toFloatMap(e.name(key), e.splitMap(str))
+		e.strict(err)
+		return v
+	}
+	e.useDefault(key, val)
+	return val
+}
+
+// GetDurationMap gets a key and returns it as a map[string]time.Duration
+func (e *Env) GetDurationMap(key string) map[string]time.Duration {
+	v, err := toDurMap(e.name(key), e.splitMap(e.get(key, "duration map")))
+	e.strict(err)
+	return v
+}
+
+// RequireDurationMap requires key and returns it as a
+// map[string]time.Duration
+func (e *Env) RequireDurationMap(key string, rules ...Rule) (map[string]time.Duration, error) {
+	m, err := e.requireMap(key, "duration map", rules)
+	if err != nil {
+		return nil, err
+	}
+	v, err := toDurMap(e.name(key), m)
+	if err != nil {
+		return nil, e.onError(err)
+	}
+	return v, nil
+}
+
+// GetOrSetDurationMap gets or sets key and returns value as
+// map[string]time.Duration
+func (e *Env) GetOrSetDurationMap(key string, val map[string]time.Duration) map[string]time.Duration {
+	if str, ok := e.present(key, "duration map"); ok {
+		v, err := toDurMap(e.name(key), e.splitMap(str))
+		e.strict(err)
+		return v
+	}
+	e.setDefault(key, val)
+	return val
+}
+
+// GetOrDurationMap gets key and returns value as map[string]time.Duration
+// or the default
+func (e *Env) GetOrDurationMap(key string, val map[string]time.Duration) map[string]time.Duration {
+	if str, ok := e.present(key, "duration map"); ok {
+		v, err := toDurMap(e.name(key), e.splitMap(str))
+		e.strict(err)
+		return v
+	}
+	e.useDefault(key, val)
+	return val
+}
+
+// GetBoolMap gets a key and returns it as a map[string]bool
+func (e *Env) GetBoolMap(key string) map[string]bool {
+	v, err := toBoolMap(e.name(key), e.splitMap(e.get(key, "bool map")))
+	e.strict(err)
+	return v
+}
+
+// RequireBoolMap requires key and returns it as a map[string]bool
+func (e *Env) RequireBoolMap(key string, rules ...Rule) (map[string]bool, error) {
+	m, err := e.requireMap(key, "bool map", rules)
+	if err != nil {
+		return nil, err
+	}
+	v, err := toBoolMap(e.name(key), m)
+	if err != nil {
+		return nil, e.onError(err)
+	}
+	return v, nil
+}
+
+// GetOrSetBoolMap gets or sets key and returns value as map[string]bool
+func (e *Env) GetOrSetBoolMap(key string, val map[string]bool) map[string]bool {
+	if str, ok := e.present(key, "bool map"); ok {
+		v, err := toBoolMap(e.name(key), e.splitMap(str))
+		e.strict(err)
+		return v
+	}
+	e.setDefault(key, val)
+	return val
+}
+
+// GetOrBoolMap gets key and returns value as map[string]bool or the default
+func (e *Env) GetOrBoolMap(key string, val map[string]bool) map[string]bool {
+	if str, ok := e.present(key, "bool map"); ok {
+		v, err := toBoolMap(e.name(key), e.splitMap(str))
+		e.strict(err)
+		return v
+	}
+	e.useDefault(key, val)
+	return val
+}
+
+// HELPERS
+func (e *Env) requireMap(key, typ string, rules []Rule) (map[string]string, error) {
+	str, err := e.require(key, typ, presence(rules))
+	if err != nil {
+		return nil, err
+	}
+
+	m := e.splitMap(str)
+	for _, k := range sortedKeys(m) {
+		if err := validate(e.name(key), typ, m[k], rules); err != nil {
+			return nil, e.onError(err)
+		}
+	}
+
+	return m, nil
+}
+
+func (e *Env) splitMap(val string) map[string]string {
+	sep := separator(e.PairSeparator, ",")
+	kv := separator(e.KeyValueSeparator, "=")
+
+	m := make(map[string]string)
+	for _, pair := range splitQuoted(val, sep) {
+		k, v, ok := cutQuoted(pair, kv)
+		if !ok && isQuoted(pair) {
+			// the whole pair is quoted
+			k, v, _ = strings.Cut(unquoteField(pair), kv)
+			k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		} else {
+			k, v = unquoteField(k), unquoteField(v)
+		}
+
+		if k == "" && v == "" && !ok {
+			continue
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// joinMap encodes a map as pairs sorted by key, quoting keys and values
+// separately so that they read back as they are
+func (e *Env) joinMap(rv reflect.Value) string {
+	sep := separator(e.PairSeparator, ",")
+	kv := separator(e.KeyValueSeparator, "=")
+
+	pairs := make([]string, 0, rv.Len())
+	for _, k := range rv.MapKeys() {
+		key := quoteField(e.toString(k.Interface()), sep, kv)
+		val := quoteField(e.toString(rv.MapIndex(k).Interface()), sep)
+		pairs = append(pairs, key+kv+val)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, sep)
+}
+
+// quoteField quotes a map key or value if it contains any of seps or
+// quotes, or has leading or trailing whitespace
+func quoteField(s string, seps ...string) string {
+	quote := strings.Contains(s, "\"") || s != strings.TrimSpace(s)
+	for _, sep := range seps {
+		quote = quote || strings.Contains(s, sep)
+	}
+
+	if !quote {
+		return s
+	}
+
+	s = strings.Replace(s, "\\", "\\\\", -1)
+	s = strings.Replace(s, "\"", "\\\"", -1)
+	return "\"" + s + "\""
+}
+
+// unquoteField trims whitespace around s, then unquotes it if it's quoted
+func unquoteField(s string) string {
+	s = strings.TrimSpace(s)
+	if !isQuoted(s) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(s)-1; i++ {
+		if s[i] == '\\' && i+1 < len(s)-1 {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// isQuoted reports whether s, trimmed of whitespace, is a single quoted
+// string
+func isQuoted(s string) bool {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '"' {
+		return false
+	}
+
+	end := quoteEnd(s, 0)
+	return end == len(s)-1
+}
+
+// quoteEnd returns the index of the quote closing the one at start, or -1
+func quoteEnd(s string, start int) int {
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// splitQuoted splits s on sep outside of quotes, keeping the quotes
+func splitQuoted(s, sep string) []string {
+	parts := make([]string, 0)
+	for s != "" {
+		part, rest, ok := cutQuoted(s, sep)
+		parts = append(parts, part)
+		if !ok {
+			break
+		}
+		s = rest
+	}
+	return parts
+}
+
+// cutQuoted slices s around the first sep outside of quotes
+func cutQuoted(s, sep string) (string, string, bool) {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' {
+			if end := quoteEnd(s, i); end >= 0 {
+				i = end
+				continue
+			}
+		}
+
+		if strings.HasPrefix(s[i:], sep) {
+			return s[:i], s[i+len(sep):], true
+		}
+	}
+	return s, "", false
+}
+
+func toIntMap(key string, m map[string]string) (map[string]int, error) {
+	ints := make(map[string]int, len(m))
+	for k, v := range m {
+		c, err := parseInt(key, v)
+		if err != nil {
+			return nil, err
+		}
+		ints[k] = c
+	}
+	return ints, nil
+}
+
+func toFloatMap(key string, m map[string]string) (map[string]float64, error) {
+	floats := make(map[string]float64, len(m))
+	for k, v := range m {
+		c, err := parseFloat64(key, v)
+		if err != nil {
+			return nil, err
+		}
+		floats[k] = c
+	}
+	return floats, nil
+}
+
+func toDurMap(key string, m map[string]string) (map[string]time.Duration, error) {
+	durs := make(map[string]time.Duration, len(m))
+	for k, v := range m {
+		c, err := parseDur(key, v)
+		if err != nil {
+			return nil, err
+		}
+		durs[k] = c
+	}
+	return durs, nil
+}
+
+func toBoolMap(key string, m map[string]string) (map[string]bool, error) {
+	bools := make(map[string]bool, len(m))
+	for k, v := range m {
+		c, err := parseBool(key, v)
+		if err != nil {
+			return nil, err
+		}
+		bools[k] = c
+	}
+	return bools, nil
+}
@@ -0,0 +1,138 @@
+package env
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+func TestGetMap(T *testing.T) {
+	defer os.Unsetenv("M_MAP")
+
+	Go(T).AssertLength(GetMap("M_MAP"), 0)
+
+	Set("M_MAP", "X-A=1, X-B = 2,\"X-C=3,4\",X-D,X-E=\"5,6\"")
+	Go(T).AssertDeepEqual(GetMap("M_MAP"), map[string]string{
+		"X-A": "1",
+		"X-B": "2",
+		"X-C": "3,4",
+		"X-D": "",
+		"X-E": "5,6",
+	})
+}
+
+func TestSetMap_roundTrip(T *testing.T) {
+	defer os.Unsetenv("M_MAP")
+
+	m := map[string]string{"a=b": "1", "k": " v ", "q\"": "x,\"y\"", "e": ""}
+	Set("M_MAP", m)
+	Go(T).AssertEqual(Get("M_MAP"), `"a=b"=1,"q\""="x,\"y\"",e=,k=" v "`)
+	Go(T).AssertDeepEqual(GetMap("M_MAP"), m)
+}
+
+func TestRequireMap(T *testing.T) {
+	defer os.Unsetenv("M_MAP")
+
+	m, e := RequireMap("M_MAP")
+	Go(T).AssertEqual(e.Error(), "missing required map from M_MAP")
+	Go(T).AssertNil(m)
+
+	Set("M_MAP", "a=debug,b=info")
+	m, e = RequireMap("M_MAP", OneOf("debug", "info"))
+	Go(T).AssertNil(e)
+	Go(T).AssertDeepEqual(m, map[string]string{"a": "debug", "b": "info"})
+
+	Set("M_MAP", "a=debug,b=verbose")
+	_, e = RequireMap("M_MAP", OneOf("debug", "info"))
+	Go(T).AssertEqual(e.Error(), "invalid map from M_MAP: \"verbose\" does not satisfy oneof=debug info")
+}
+
+func TestGetOrSetMap(T *testing.T) {
+	defer os.Unsetenv("M_MAP")
+
+	def := map[string]string{"b": "2,3", "a": "1"}
+	Go(T).AssertDeepEqual(GetOrSetMap("M_MAP", def), def)
+	Go(T).AssertEqual(Get("M_MAP"), "a=1,b=\"2,3\"")
+	Go(T).AssertDeepEqual(GetOrSetMap("M_MAP", nil), def)
+}
+
+func TestGetIntMap(T *testing.T) {
+	defer os.Unsetenv("M_MAP")
+
+	def := map[string]int{"a": 1, "b": 2}
+	Go(T).AssertDeepEqual(GetOrSetIntMap("M_MAP", def), def)
+	Go(T).AssertDeepEqual(GetIntMap("M_MAP"), def)
+
+	m, e := RequireIntMap("M_MAP", Max(1))
+	Go(T).RefuteNil(e)
+	Go(T).AssertNil(m)
+}
+
+func TestGetFloatMap(T *testing.T) {
+	defer os.Unsetenv("M_MAP")
+
+	def := map[string]float64{"a": 1.5}
+	Go(T).AssertDeepEqual(GetOrSetFloatMap("M_MAP", def), def)
+	Go(T).AssertDeepEqual(GetFloatMap("M_MAP"), def)
+
+	m, e := RequireFloatMap("M_MAP")
+	Go(T).AssertNil(e)
+	Go(T).AssertDeepEqual(m, def)
+}
+
+func TestGetDurationMap(T *testing.T) {
+	defer os.Unsetenv("M_MAP")
+
+	def := map[string]time.Duration{"read": time.Second, "write": time.Minute}
+	Go(T).AssertDeepEqual(GetOrSetDurationMap("M_MAP", def), def)
+	Go(T).AssertEqual(Get("M_MAP"), "read=1s,write=1m0s")
+	Go(T).AssertDeepEqual(GetDurationMap("M_MAP"), def)
+
+	m, e := RequireDurationMap("M_MAP")
+	Go(T).AssertNil(e)
+	Go(T).AssertDeepEqual(m, def)
+}
+
+func TestGetBoolMap(T *testing.T) {
+	defer os.Unsetenv("M_MAP")
+
+	def := map[string]bool{"a": true, "b": false}
+	Go(T).AssertDeepEqual(GetOrSetBoolMap("M_MAP", def), def)
+	Go(T).AssertDeepEqual(GetBoolMap("M_MAP"), def)
+
+	m, e := RequireBoolMap("M_MAP")
+	Go(T).AssertNil(e)
+	Go(T).AssertDeepEqual(m, def)
+}
+
+func TestMapSeparators(T *testing.T) {
+	defer os.Unsetenv("M_MAP")
+	defer func() {
+		PairSeparator = ","
+		KeyValueSeparator = "="
+	}()
+
+	PairSeparator = ";"
+	KeyValueSeparator = ":"
+
+	Set("M_MAP", map[string]string{"a": "1,2", "b": "x;y"})
+	Go(T).AssertEqual(Get("M_MAP"), "a:1,2;b:\"x;y\"")
+	Go(T).AssertDeepEqual(GetMap("M_MAP"), map[string]string{"a": "1,2", "b": "x;y"})
+}
+
+func TestMapSeparators_empty(T *testing.T) {
+	defer os.Unsetenv("M_MAP")
+	defer func() {
+		PairSeparator = ","
+		KeyValueSeparator = "="
+	}()
+
+	PairSeparator = ""
+	KeyValueSeparator = ""
+
+	Set("M_MAP", map[string]string{"a": "1", "b": "2"})
+	Go(T).AssertEqual(Get("M_MAP"), "a=1,b=2")
+	Go(T).AssertDeepEqual(GetMap("M_MAP"), map[string]string{"a": "1", "b": "2"})
+}
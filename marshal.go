@@ -0,0 +1,147 @@
+package env
+
+import (
+	"bufio"
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Marshal walks the struct (or pointer to struct) v and returns its fields as
+// environment key/value pairs, using the same tags and naming as Unmarshal
+// and the same encoding as Set. Fields implementing encoding.TextMarshaler
+// are encoded with it, and nil pointer-to-struct fields are omitted.
+func (e *Env) Marshal(v interface{}) (map[string]string, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("env: Marshal requires a struct or pointer to a struct, got %T", v)
+	}
+
+	if err := checkRecursion(rv.Type(), nil); err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]string)
+	if err := e.marshalStruct(rv, "", m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// WriteFile marshals v and writes it to filename in the format read by Load,
+// sorted by key
+func (e *Env) WriteFile(filename string, v interface{}) error {
+	m, err := e.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	for _, key := range sortedKeys(m) {
+		fmt.Fprintf(w, "%s=%s\n", key, quote(m[key]))
+	}
+
+	return w.Flush()
+}
+
+func (e *Env) marshalStruct(rv reflect.Value, prefix string, m map[string]string) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		fv := rv.Field(i)
+
+		if nested, ok := nestedPrefix(field, prefix); ok {
+			if field.Type.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+
+			if err := e.marshalStruct(fv, nested, m); err != nil {
+				return err
+			}
+			continue
+		}
+
+		key, ok := fieldKey(field, prefix)
+		if !ok {
+			continue
+		}
+
+		str, err := e.encodeField(fv)
+		if err != nil {
+			return fmt.Errorf("env: %s (%s): %v", field.Name, key, err)
+		}
+		m[key] = str
+	}
+
+	return nil
+}
+
+// encodeField encodes fv using encoding.TextMarshaler when implemented,
+// falling back to toString
+func (e *Env) encodeField(fv reflect.Value) (string, error) {
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			return "", nil
+		}
+		fv = fv.Elem()
+	}
+
+	if fv.CanAddr() {
+		if t, ok := fv.Addr().Interface().(encoding.TextMarshaler); ok {
+			b, err := t.MarshalText()
+			return string(b), err
+		}
+	}
+
+	if t, ok := fv.Interface().(encoding.TextMarshaler); ok {
+		b, err := t.MarshalText()
+		return string(b), err
+	}
+
+	return e.toString(fv.Interface()), nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// quote wraps values which wouldn't survive Load unquoted in double quotes,
+// escaping backslashes first so the escapes added after them are kept, and
+// dollar signs so they aren't expanded
+func quote(val string) string {
+	if !strings.ContainsAny(val, " \t\r\n#\"'=\\$") {
+		return val
+	}
+
+	val = strings.Replace(val, "\\", "\\\\", -1)
+	val = strings.Replace(val, "$", "\\$", -1)
+	val = strings.Replace(val, "\"", "\\\"", -1)
+	val = strings.Replace(val, "\n", "\\n", -1)
+	val = strings.Replace(val, "\r", "\\r", -1)
+
+	return "\"" + val + "\""
+}
@@ -0,0 +1,147 @@
+package env
+
+import (
+	. "github.com/jmervine/env/_fixtures"
+
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+func TestMarshal(T *testing.T) {
+	defer UnsetFixtures()
+	SetFixtures()
+
+	var c fixtureConfig
+	Go(T).AssertNil(Unmarshal(&c))
+
+	m, err := Marshal(c)
+	Go(T).AssertNil(err)
+	Go(T).AssertLength(m, len(Fixtures))
+
+	for key := range Fixtures {
+		Go(T).AssertEqual(m[key], Get(key))
+	}
+
+	m, err = Marshal(&c)
+	Go(T).AssertNil(err)
+	Go(T).AssertLength(m, len(Fixtures))
+}
+
+func TestMarshal_nested(T *testing.T) {
+	type DB struct {
+		Host string
+	}
+
+	var c struct {
+		DB      DB
+		Missing *DB `prefix:"MISSING_"`
+		Skip    int `env:"-"`
+	}
+	c.DB.Host = "localhost"
+
+	m, err := Marshal(c)
+	Go(T).AssertNil(err)
+	Go(T).AssertDeepEqual(m, map[string]string{"DB_HOST": "localhost"})
+}
+
+func TestMarshal_invalid(T *testing.T) {
+	_, err := Marshal("string")
+	Go(T).RefuteNil(err)
+
+	_, err = Marshal(nil)
+	Go(T).RefuteNil(err)
+}
+
+func TestWriteFile(T *testing.T) {
+	defer UnsetFixtures()
+	SetFixtures()
+	Set("F_STRING", "sample file")
+
+	var c fixtureConfig
+	Go(T).AssertNil(Unmarshal(&c))
+
+	dir := T.TempDir()
+
+	file := filepath.Join(dir, "fixtures.env")
+	Go(T).AssertNil(WriteFile(file, c))
+
+	UnsetFixtures()
+	Go(T).AssertNil(Load(file))
+
+	var r fixtureConfig
+	Go(T).AssertNil(Unmarshal(&r))
+	Go(T).AssertDeepEqual(r, c)
+}
+
+func TestWriteFile_escapes(T *testing.T) {
+	defer UnsetFixtures()
+
+	dir := T.TempDir()
+
+	c := struct {
+		Path  string `env:"F_STRING"`
+		Quote string `env:"F_BYTES"`
+		Ref   string `env:"F_INT"`
+		Brace string `env:"F_BOOL"`
+	}{`C:\tmp dir`, `say "hi\"`, "pa$word", "${HOME}"}
+
+	file := filepath.Join(dir, "escapes.env")
+	Go(T).AssertNil(WriteFile(file, c))
+	Go(T).AssertNil(Load(file))
+
+	r := c
+	r.Path, r.Quote, r.Ref, r.Brace = "", "", "", ""
+	Go(T).AssertNil(Unmarshal(&r))
+	Go(T).AssertDeepEqual(r, c)
+}
+
+func TestMarshal_lists(T *testing.T) {
+	type lists struct {
+		Hosts  []string
+		Ports  []int
+		TTLs   []time.Duration
+		Labels map[string]string
+		Limits map[string]int
+	}
+
+	c := lists{
+		Hosts:  []string{"a", "b,c"},
+		Ports:  []int{80, 443},
+		TTLs:   []time.Duration{time.Second, time.Minute},
+		Labels: map[string]string{"x": "1", "y": "2"},
+		Limits: map[string]int{"cpu": 2},
+	}
+
+	m, err := Marshal(c)
+	Go(T).AssertNil(err)
+	Go(T).AssertEqual(m["HOSTS"], `a,"b,c"`)
+
+	src := MapSource{}
+	for k, v := range m {
+		src[k] = v
+	}
+
+	var r lists
+	Go(T).AssertNil(New(src).Unmarshal(&r))
+	Go(T).AssertDeepEqual(r, c)
+
+	src["PORTS"] = "80,http"
+	err = New(src).Unmarshal(&r)
+	var perr *ParseError
+	Go(T).Assert(errors.As(err, &perr))
+	Go(T).AssertEqual(perr.Key, "PORTS")
+}
+
+func Test_quote(T *testing.T) {
+	Go(T).AssertEqual(quote("plain"), "plain")
+	Go(T).AssertEqual(quote("sample file"), "\"sample file\"")
+	Go(T).AssertEqual(quote("a#b"), "\"a#b\"")
+	Go(T).AssertEqual(quote("a\nb"), "\"a\\nb\"")
+	Go(T).AssertEqual(quote(`C:\tmp`), `"C:\\tmp"`)
+	Go(T).AssertEqual(quote(`say "hi\"`), `"say \"hi\\\""`)
+	Go(T).AssertEqual(quote("${HOME}"), `"\${HOME}"`)
+}
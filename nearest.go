@@ -0,0 +1,74 @@
+package env
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// StopMarkers are the files or directories marking the root of a project,
+// where FindNearest stops searching
+var StopMarkers = []string{"go.mod", ".git"}
+
+// FindNearest returns the path of name in dir, or the nearest of its
+// parents, stopping at the first directory containing one of StopMarkers
+// or the filesystem root. The error wraps fs.ErrNotExist if name isn't
+// found.
+func FindNearest(dir, name string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	start := dir
+
+	for {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if isProjectRoot(dir) || parent == dir {
+			return "", fmt.Errorf("env: %s not found in %s or its parents: %w", name, start, fs.ErrNotExist)
+		}
+		dir = parent
+	}
+}
+
+func isProjectRoot(dir string) bool {
+	for _, marker := range StopMarkers {
+		if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LoadNearest loads name from the working directory, or the nearest of its
+// parents, as found by FindNearest, returning the path which was loaded.
+// This lets tests and tools run from any package in a project.
+//
+// e.g.:
+//
+//	path, err := env.LoadNearest(".env")
+func (e *Env) LoadNearest(name string) (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	return e.LoadNearestFrom(dir, name)
+}
+
+// LoadNearestFrom does the same thing as LoadNearest, but starts searching
+// from dir
+func (e *Env) LoadNearestFrom(dir, name string) (string, error) {
+	path, err := FindNearest(dir, name)
+	if err != nil {
+		return "", err
+	}
+
+	return path, e.loadFile(path, false)
+}
@@ -0,0 +1,65 @@
+package env
+
+import (
+	. "github.com/jmervine/env/_fixtures"
+
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+func TestFindNearest(T *testing.T) {
+	T.Parallel()
+
+	root := T.TempDir()
+	project := filepath.Join(root, "project")
+	pkg := filepath.Join(project, "internal", "pkg")
+	Go(T).AssertNil(os.MkdirAll(pkg, 0o755))
+
+	Go(T).AssertNil(os.WriteFile(filepath.Join(root, ".env"), []byte("OUTSIDE=1\n"), 0o644))
+	Go(T).AssertNil(os.WriteFile(filepath.Join(project, "go.mod"), []byte("module project\n"), 0o644))
+	Go(T).AssertNil(os.WriteFile(filepath.Join(project, ".env"), []byte("PORT=3000\n"), 0o644))
+	Go(T).AssertNil(os.Mkdir(filepath.Join(pkg, ".env"), 0o755))
+
+	// directories named name are skipped
+	path, err := FindNearest(pkg, ".env")
+	Go(T).AssertNil(err)
+	Go(T).AssertEqual(path, filepath.Join(project, ".env"))
+
+	// the search stops at the project root
+	_, err = FindNearest(pkg, "missing.env")
+	Go(T).Assert(errors.Is(err, fs.ErrNotExist))
+
+	Go(T).AssertNil(os.Remove(filepath.Join(project, ".env")))
+	_, err = FindNearest(pkg, ".env")
+	Go(T).Assert(errors.Is(err, fs.ErrNotExist))
+}
+
+func TestLoadNearestFrom(T *testing.T) {
+	T.Parallel()
+
+	project := T.TempDir()
+	pkg := filepath.Join(project, "pkg")
+	Go(T).AssertNil(os.MkdirAll(filepath.Join(project, ".git"), 0o755))
+	Go(T).AssertNil(os.MkdirAll(pkg, 0o755))
+	Go(T).AssertNil(os.WriteFile(filepath.Join(project, ".env"), []byte("PORT=3000\n"), 0o644))
+
+	src := MapSource{}
+	path, err := New(src).LoadNearestFrom(pkg, ".env")
+	Go(T).AssertNil(err)
+	Go(T).AssertEqual(path, filepath.Join(project, ".env"))
+	Go(T).AssertEqual(src["PORT"], "3000")
+}
+
+func TestLoadNearest(T *testing.T) {
+	defer UnsetFixtures()
+
+	path, err := LoadNearest(env)
+	Go(T).AssertNil(err)
+	Go(T).AssertEqual(filepath.Base(path), "fixtures.env")
+	Go(T).AssertEqual(os.Getenv("F_INT"), "9")
+}
@@ -0,0 +1,80 @@
+package env
+
+import "strings"
+
+// PrefixSource is a view of the keys in a Source which start with a prefix,
+// with the prefix stripped
+type PrefixSource struct {
+	source Source
+	prefix string
+}
+
+// Prefixed returns a view of the keys in source starting with prefix, so
+// looking up HOST reads prefix + "HOST"
+func Prefixed(prefix string, source Source) *PrefixSource {
+	return &PrefixSource{source: source, prefix: prefix}
+}
+
+// Prefix returns the prefix added to keys
+func (s *PrefixSource) Prefix() string {
+	return s.prefix
+}
+
+// Lookup returns the value of prefix + key
+func (s *PrefixSource) Lookup(key string) (string, bool) {
+	return s.source.Lookup(s.prefix + key)
+}
+
+// Keys returns the sorted keys starting with prefix, with the prefix
+// stripped
+func (s *PrefixSource) Keys() []string {
+	keys := make([]string, 0)
+	for _, key := range s.source.Keys() {
+		if k, ok := strings.CutPrefix(key, s.prefix); ok && k != "" {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
+
+// Set sets prefix + key, returning ErrReadOnly if the underlying Source
+// doesn't implement Setter
+func (s *PrefixSource) Set(key, val string) error {
+	setter, ok := s.source.(Setter)
+	if !ok {
+		return ErrReadOnly
+	}
+
+	return setter.Set(s.prefix+key, val)
+}
+
+// Explain returns the candidates for prefix + key in the underlying Source,
+// keeping their fully qualified keys
+func (s *PrefixSource) Explain(key string) []Origin {
+	return explain(s.source, s.prefix+key)
+}
+
+// WithPrefix returns a view of e where every key is read and written with
+// prefix added, e.g.:
+//
+//	payments := env.WithPrefix("PAYMENTS_")
+//	host := payments.Get("HOST") // reads PAYMENTS_HOST
+//
+// Keys lists only the keys starting with prefix, with it stripped, while
+// errors and Explain report fully qualified keys. The view shares e's
+// settings as they are when it's created, and prefixes nest, so
+// WithPrefix("A_").WithPrefix("B_") reads A_B_ keys.
+func (e *Env) WithPrefix(prefix string) *Env {
+	sub := *e
+	sub.source = Prefixed(prefix, e.source)
+	sub.prefix = e.prefix + prefix
+
+	return &sub
+}
+
+// name returns key fully qualified with e's prefix, for errors and
+// provenance
+func (e *Env) name(key string) string {
+	return e.prefix + key
+}
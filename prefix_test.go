@@ -0,0 +1,119 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+func TestWithPrefix(T *testing.T) {
+	T.Parallel()
+
+	src := MapSource{"PAYMENTS_HOST": "localhost", "PAYMENTS_PORT": "80a", "PAYMENTS_TTL": "1m", "HOST": "example.com"}
+	e := New(src).WithPrefix("PAYMENTS_")
+
+	Go(T).AssertEqual(e.Get("HOST"), "localhost")
+	Go(T).AssertEqual(e.GetDuration("TTL").String(), "1m0s")
+	Go(T).AssertDeepEqual(e.Keys(), []string{"HOST", "PORT", "TTL"})
+
+	_, err := e.GetIntE("PORT")
+	var perr *ParseError
+	Go(T).Assert(errors.As(err, &perr))
+	Go(T).AssertEqual(perr.Key, "PAYMENTS_PORT")
+
+	_, err = e.Require("MISSING")
+	Go(T).AssertEqual(err.Error(), "missing required string from PAYMENTS_MISSING")
+
+	err = e.RequireAll("HOST", "ADDR")
+	Go(T).AssertEqual(err.Error(), "1 environment error:\n  - missing required string from PAYMENTS_ADDR")
+
+	Go(T).AssertNil(e.Set("ADDR", "0.0.0.0"))
+	Go(T).AssertEqual(src["PAYMENTS_ADDR"], "0.0.0.0")
+
+	Go(T).AssertEqual(e.GetOrSetInt("RETRIES", 3), 3)
+	Go(T).AssertEqual(src["PAYMENTS_RETRIES"], "3")
+
+	o, ok := e.Provenance("RETRIES")
+	Go(T).Assert(ok)
+	Go(T).AssertEqual(o, Origin{Key: "PAYMENTS_RETRIES", Value: "3", Source: "default"})
+
+	o, _ = e.Provenance("HOST")
+	Go(T).AssertEqual(o, Origin{Key: "PAYMENTS_HOST", Value: "localhost", Source: "map"})
+}
+
+func TestWithPrefix_nested(T *testing.T) {
+	T.Parallel()
+
+	e := New(MapSource{"A_B_C": "1", "A_C": "2"}).WithPrefix("A_").WithPrefix("B_")
+
+	Go(T).AssertEqual(e.GetInt("C"), 1)
+	Go(T).AssertDeepEqual(e.Keys(), []string{"C"})
+
+	_, err := e.Require("D")
+	Go(T).AssertEqual(err.Error(), "missing required string from A_B_D")
+}
+
+func TestWithPrefix_unmarshal(T *testing.T) {
+	T.Parallel()
+
+	var c struct {
+		Host string
+		Port int `required:"true"`
+	}
+
+	e := New(MapSource{"DB_HOST": "localhost"}).WithPrefix("DB_")
+
+	err := e.Unmarshal(&c)
+	Go(T).AssertEqual(err.Error(), "missing required int from DB_PORT")
+	Go(T).AssertEqual(c.Host, "localhost")
+}
+
+func TestWithPrefix_checker(T *testing.T) {
+	T.Parallel()
+
+	var (
+		i int
+		d time.Duration
+	)
+
+	errs := New(MapSource{"PAY_I": "1a", "PAY_N": "1x"}).WithPrefix("PAY_").NewChecker().
+		Int("I", &i).
+		Value("N", &d).
+		Errors()
+
+	Go(T).AssertLength(errs, 2)
+
+	for n, key := range []string{"PAY_I", "PAY_N"} {
+		var perr *ParseError
+		Go(T).Assert(errors.As(errs[n], &perr))
+		Go(T).AssertEqual(perr.Key, key)
+	}
+}
+
+func TestWithPrefix_std(T *testing.T) {
+	defer os.Unsetenv("PAYMENTS_HOST")
+	os.Setenv("PAYMENTS_HOST", "localhost")
+
+	e := WithPrefix("PAYMENTS_")
+	Go(T).AssertEqual(e.Get("HOST"), "localhost")
+	Go(T).AssertContains(e.Keys(), "HOST")
+}
+
+func TestPrefixed(T *testing.T) {
+	T.Parallel()
+
+	file, err := NewDotenvFileSource(env)
+	Go(T).AssertNil(err)
+
+	src := Prefixed("F_", file)
+	Go(T).AssertEqual(src.Prefix(), "F_")
+	Go(T).AssertContains(src.Keys(), "INT")
+	Go(T).AssertEqual(src.Set("INT", "1"), ErrReadOnly)
+
+	Go(T).AssertDeepEqual(src.Explain("INT"), []Origin{
+		{Key: "F_INT", Value: "9", Source: "dotenv", File: env, Line: 4},
+	})
+}
@@ -0,0 +1,145 @@
+package env
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Origin describes where a candidate value for a key comes from
+type Origin struct {
+	Key   string
+	Value string
+
+	// Source names where the value was found: "env" for the process
+	// environment, "map", "dotenv", or "default" for GetOr- and GetOrSet-
+	// defaults
+	Source string
+
+	// File and Line locate values read from dotenv files
+	File string
+	Line int
+}
+
+// String formats o as e.g. `PORT="3000" from dotenv (.env:2)`
+func (o Origin) String() string {
+	s := fmt.Sprintf("%s=%q from %s", o.Key, o.Value, o.Source)
+	if o.File != "" {
+		s += fmt.Sprintf(" (%s:%d)", o.File, o.Line)
+	}
+
+	return s
+}
+
+// Explainer is implemented by Sources which can report where their values
+// come from
+type Explainer interface {
+	// Explain returns every candidate value for key, highest precedence
+	// first
+	Explain(key string) []Origin
+}
+
+// Explain returns every candidate value for key, highest precedence first,
+// so the first is the value returned by Get
+//
+// Values set by Load, Overload and GetOrSet- methods are reported as
+// coming from their file or "default", as long as they haven't been changed
+// since, and defaults passed to GetOr- methods are listed last.
+//
+// e.g.:
+//
+//	for _, o := range env.Explain("PORT") {
+//	    fmt.Println(o)
+//	}
+func (e *Env) Explain(key string) []Origin {
+	candidates := explain(e.source, key)
+
+	if o, ok := e.origins.written(e.name(key)); ok && len(candidates) > 0 && candidates[0].Value == o.Value {
+		candidates[0] = o
+	}
+
+	if o, ok := e.origins.fallback(e.name(key)); ok {
+		candidates = append(candidates, o)
+	}
+
+	return candidates
+}
+
+// Provenance returns where key's value comes from, or false if it isn't set
+// and no default has been used for it
+func (e *Env) Provenance(key string) (Origin, bool) {
+	candidates := e.Explain(key)
+	if len(candidates) == 0 {
+		return Origin{}, false
+	}
+
+	return candidates[0], true
+}
+
+// explain returns src's candidates for key, naming Sources which don't
+// implement Explainer by their type
+func explain(src Source, key string) []Origin {
+	if ex, ok := src.(Explainer); ok {
+		return ex.Explain(key)
+	}
+
+	return lookupOrigins(src, key, fmt.Sprintf("%T", src))
+}
+
+// origins records where values written by an Env came from, and the
+// defaults it has fallen back to
+type origins struct {
+	mu        sync.Mutex
+	writes    map[string]Origin
+	fallbacks map[string]Origin
+}
+
+func newOrigins() *origins {
+	return &origins{
+		writes:    make(map[string]Origin),
+		fallbacks: make(map[string]Origin),
+	}
+}
+
+// stdOrigins is shared by every default Env returned by std
+var stdOrigins = newOrigins()
+
+func (o *origins) write(origin Origin) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.writes[origin.Key] = origin
+}
+
+func (o *origins) forget(key string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.writes, key)
+}
+
+func (o *origins) written(key string) (Origin, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	origin, ok := o.writes[key]
+	return origin, ok
+}
+
+func (o *origins) fallback(key string) (Origin, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	origin, ok := o.fallbacks[key]
+	return origin, ok
+}
+
+// setDefault sets key to the default val for GetOrSet- methods
+func (e *Env) setDefault(key string, val interface{}) {
+	str := e.toString(val)
+	if err := e.set(key, str); err == nil {
+		e.origins.write(Origin{Key: e.name(key), Value: str, Source: "default"})
+	}
+}
+
+// useDefault records that GetOr- methods returned the default val for key
+func (e *Env) useDefault(key string, val interface{}) {
+	e.origins.mu.Lock()
+	defer e.origins.mu.Unlock()
+	e.origins.fallbacks[e.name(key)] = Origin{Key: e.name(key), Value: e.toString(val), Source: "default"}
+}
@@ -0,0 +1,74 @@
+package env
+
+import (
+	. "github.com/jmervine/env/_fixtures"
+
+	"testing"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+func TestExplain(T *testing.T) {
+	T.Parallel()
+
+	defaults := MapSource{"F_INT": "1", "PORT": "3000"}
+	file, err := NewDotenvFileSource(env)
+	Go(T).AssertNil(err)
+
+	e := New(Layered(defaults, file))
+
+	Go(T).AssertDeepEqual(e.Explain("F_INT"), []Origin{
+		{Key: "F_INT", Value: "9", Source: "dotenv", File: env, Line: 4},
+		{Key: "F_INT", Value: "1", Source: "map"},
+	})
+	Go(T).AssertEqual(e.Explain("F_INT")[0].String(), `F_INT="9" from dotenv (`+env+`:4)`)
+	Go(T).AssertEqual(e.Explain("PORT")[0].String(), `PORT="3000" from map`)
+	Go(T).AssertLength(e.Explain("MISSING"), 0)
+
+	o, ok := e.Provenance("F_WHITESPACE")
+	Go(T).Assert(ok)
+	Go(T).AssertEqual(o.Line, 12)
+
+	_, ok = e.Provenance("MISSING")
+	Go(T).Refute(ok)
+}
+
+func TestExplain_defaults(T *testing.T) {
+	T.Parallel()
+
+	e := New(MapSource{})
+
+	Go(T).AssertEqual(e.GetOrInt("PORT", 3000), 3000)
+	o, ok := e.Provenance("PORT")
+	Go(T).Assert(ok)
+	Go(T).AssertEqual(o, Origin{Key: "PORT", Value: "3000", Source: "default"})
+
+	// the default stays a candidate once the key is set
+	e.Set("PORT", 8080)
+	Go(T).AssertDeepEqual(e.Explain("PORT"), []Origin{
+		{Key: "PORT", Value: "8080", Source: "map"},
+		{Key: "PORT", Value: "3000", Source: "default"},
+	})
+
+	Go(T).AssertEqual(e.GetOrSet("ADDR", "0.0.0.0"), "0.0.0.0")
+	o, _ = e.Provenance("ADDR")
+	Go(T).AssertEqual(o.Source, "default")
+
+	e.Set("ADDR", "0.0.0.0")
+	o, _ = e.Provenance("ADDR")
+	Go(T).AssertEqual(o.Source, "map")
+}
+
+func TestExplain_load(T *testing.T) {
+	defer UnsetFixtures()
+
+	Go(T).AssertNil(Load(env))
+
+	o, ok := Provenance("F_DURATION")
+	Go(T).Assert(ok)
+	Go(T).AssertEqual(o, Origin{Key: "F_DURATION", Value: "1h1m1s", Source: "dotenv", File: env, Line: 10})
+
+	Set("F_DURATION", "1m")
+	o, _ = Provenance("F_DURATION")
+	Go(T).AssertEqual(o, Origin{Key: "F_DURATION", Value: "1m", Source: "env"})
+}
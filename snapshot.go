@@ -0,0 +1,201 @@
+package env
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Redacted replaces the values of secret keys in Changes
+const Redacted = "[redacted]"
+
+// SecretPatterns are matched against keys, ignoring case, to decide which
+// values Diff redacts
+var SecretPatterns = []string{"PASSWORD", "PASSWD", "SECRET", "TOKEN", "CREDENTIAL", "PRIVATE", "_KEY"}
+
+// IsSecret reports whether key contains any of SecretPatterns
+func IsSecret(key string) bool {
+	key = strings.ToUpper(key)
+	for _, pattern := range SecretPatterns {
+		if strings.Contains(key, strings.ToUpper(pattern)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// State is an immutable copy of the keys and values of an Env, as returned
+// by Snapshot. It's a read-only Source, so it can be read with New.
+type State struct {
+	values map[string]string
+}
+
+// Snapshot returns a copy of every key and value in e
+//
+// e.g.:
+//
+//	before := env.Snapshot()
+//	env.Overload(".env")
+//	log.Print(env.Diff(before, env.Snapshot()))
+func (e *Env) Snapshot() State {
+	values := make(map[string]string)
+	for _, key := range e.source.Keys() {
+		if val, ok := e.source.Lookup(key); ok {
+			values[key] = val
+		}
+	}
+
+	return State{values: values}
+}
+
+// Lookup returns key's value in s
+func (s State) Lookup(key string) (string, bool) {
+	val, ok := s.values[key]
+	return val, ok
+}
+
+// Keys returns the sorted keys in s
+func (s State) Keys() []string {
+	return sortedKeys(s.values)
+}
+
+// Len returns the number of keys in s
+func (s State) Len() int {
+	return len(s.values)
+}
+
+// Map returns a copy of the keys and values in s
+func (s State) Map() map[string]string {
+	m := make(map[string]string, len(s.values))
+	for key, val := range s.values {
+		m[key] = val
+	}
+
+	return m
+}
+
+// ChangeKind is the kind of change to a key
+type ChangeKind int
+
+// Kinds of Change
+const (
+	Added ChangeKind = iota
+	Removed
+	Changed
+)
+
+// String returns "added", "removed" or "changed"
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	}
+
+	return fmt.Sprintf("ChangeKind(%d)", int(k))
+}
+
+// Change is a key which differs between two States. Old is empty for added
+// keys and New for removed ones, and both are Redacted for secret keys.
+type Change struct {
+	Key  string
+	Kind ChangeKind
+	Old  string
+	New  string
+}
+
+// String formats c as "+ KEY=new", "- KEY=old" or "~ KEY=old -> new"
+func (c Change) String() string {
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("+ %s=%s", c.Key, c.New)
+	case Removed:
+		return fmt.Sprintf("- %s=%s", c.Key, c.Old)
+	}
+
+	return fmt.Sprintf("~ %s=%s -> %s", c.Key, c.Old, c.New)
+}
+
+// Changes are the differences between two States, sorted by key
+type Changes []Change
+
+// Keys returns the changed keys
+func (c Changes) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for _, change := range c {
+		keys = append(keys, change.Key)
+	}
+
+	return keys
+}
+
+// Kind returns only the changes of kind k
+func (c Changes) Kind(k ChangeKind) Changes {
+	changes := make(Changes, 0)
+	for _, change := range c {
+		if change.Kind == k {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// String returns one change per line
+func (c Changes) String() string {
+	lines := make([]string, 0, len(c))
+	for _, change := range c {
+		lines = append(lines, change.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Diff returns the keys added, removed and changed from a to b, redacting
+// the values of keys matching SecretPatterns
+func Diff(a, b State) Changes {
+	keys := make([]string, 0)
+	for key := range a.values {
+		keys = append(keys, key)
+	}
+	for key := range b.values {
+		if _, ok := a.values[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	changes := make(Changes, 0)
+	for _, key := range keys {
+		old, inA := a.values[key]
+		val, inB := b.values[key]
+
+		var change Change
+		switch {
+		case !inA:
+			change = Change{Key: key, Kind: Added, New: val}
+		case !inB:
+			change = Change{Key: key, Kind: Removed, Old: old}
+		case old != val:
+			change = Change{Key: key, Kind: Changed, Old: old, New: val}
+		default:
+			continue
+		}
+
+		if IsSecret(key) {
+			if inA {
+				change.Old = Redacted
+			}
+			if inB {
+				change.New = Redacted
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
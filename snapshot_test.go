@@ -0,0 +1,83 @@
+package env
+
+import (
+	. "github.com/jmervine/env/_fixtures"
+
+	"testing"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+func TestSnapshot(T *testing.T) {
+	T.Parallel()
+
+	src := MapSource{"PORT": "3000", "ADDR": "0.0.0.0"}
+	e := New(src)
+
+	s := e.Snapshot()
+	Go(T).AssertEqual(s.Len(), 2)
+	Go(T).AssertDeepEqual(s.Keys(), []string{"ADDR", "PORT"})
+
+	// later changes don't affect the snapshot
+	e.Set("PORT", 8080)
+	m := s.Map()
+	m["PORT"] = "1"
+
+	val, ok := s.Lookup("PORT")
+	Go(T).Assert(ok)
+	Go(T).AssertEqual(val, "3000")
+
+	Go(T).AssertEqual(New(s).GetInt("PORT"), 3000)
+	Go(T).AssertEqual(New(s).Set("PORT", 1), ErrReadOnly)
+}
+
+func TestDiff(T *testing.T) {
+	T.Parallel()
+
+	e := New(MapSource{"PORT": "3000", "ADDR": "0.0.0.0", "DB_PASSWORD": "hunter2", "API_TOKEN": "abc"})
+	before := e.Snapshot()
+
+	e.Set("PORT", 8080)
+	e.Set("DEBUG", true)
+	e.Set("DB_PASSWORD", "hunter3")
+	e.Set("API_KEY", "xyz")
+	delete(e.Source().(MapSource), "ADDR")
+
+	changes := Diff(before, e.Snapshot())
+	Go(T).AssertDeepEqual(changes, Changes{
+		{Key: "ADDR", Kind: Removed, Old: "0.0.0.0"},
+		{Key: "API_KEY", Kind: Added, New: Redacted},
+		{Key: "DB_PASSWORD", Kind: Changed, Old: Redacted, New: Redacted},
+		{Key: "DEBUG", Kind: Added, New: "true"},
+		{Key: "PORT", Kind: Changed, Old: "3000", New: "8080"},
+	})
+
+	Go(T).AssertEqual(changes.String(), "- ADDR=0.0.0.0\n"+
+		"+ API_KEY=[redacted]\n"+
+		"~ DB_PASSWORD=[redacted] -> [redacted]\n"+
+		"+ DEBUG=true\n"+
+		"~ PORT=3000 -> 8080")
+
+	Go(T).AssertDeepEqual(changes.Kind(Added).Keys(), []string{"API_KEY", "DEBUG"})
+	Go(T).AssertEqual(Changed.String(), "changed")
+	Go(T).AssertLength(Diff(before, before), 0)
+}
+
+func TestDiff_load(T *testing.T) {
+	defer UnsetFixtures()
+
+	before := Snapshot()
+	Go(T).AssertNil(Load(env))
+
+	changes := Diff(before, Snapshot())
+	Go(T).AssertContains(changes.Keys(), "F_INT")
+	Go(T).AssertLength(changes.Kind(Added), len(changes))
+}
+
+func TestIsSecret(T *testing.T) {
+	Go(T).Assert(IsSecret("DATABASE_PASSWORD"))
+	Go(T).Assert(IsSecret("stripe_secret"))
+	Go(T).Assert(IsSecret("AWS_ACCESS_KEY"))
+	Go(T).Refute(IsSecret("PORT"))
+	Go(T).Refute(IsSecret("KEYBOARD"))
+}
@@ -0,0 +1,181 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ErrReadOnly is returned when setting a key on an Env whose Source doesn't
+// implement Setter
+var ErrReadOnly = errors.New("env: source is read-only")
+
+// Source is the backing store read by an Env
+type Source interface {
+	// Lookup returns key's value and whether it's set
+	Lookup(key string) (string, bool)
+
+	// Keys returns every key which is set
+	Keys() []string
+}
+
+// Setter is implemented by Sources which can be written to, allowing Set,
+// GetOrSet- methods, Load and Overload
+type Setter interface {
+	Set(key, val string) error
+}
+
+// OSSource reads and writes the process environment
+type OSSource struct{}
+
+// Lookup calls os.LookupEnv
+func (OSSource) Lookup(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+// Keys returns the sorted keys of os.Environ
+func (OSSource) Keys() []string {
+	keys := make([]string, 0)
+	for _, kv := range os.Environ() {
+		if key, _, _ := strings.Cut(kv, "="); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Set calls os.Setenv
+func (OSSource) Set(key, val string) error {
+	return os.Setenv(key, val)
+}
+
+// Explain returns key's value from the process environment as coming from
+// "env"
+func (s OSSource) Explain(key string) []Origin {
+	return lookupOrigins(s, key, "env")
+}
+
+// MapSource reads and writes a map, e.g.:
+//
+//	e := env.New(env.MapSource{"PORT": "3000"})
+//
+// It isn't safe for concurrent writes.
+type MapSource map[string]string
+
+// Lookup returns key's value in m
+func (m MapSource) Lookup(key string) (string, bool) {
+	val, ok := m[key]
+	return val, ok
+}
+
+// Keys returns the sorted keys of m
+func (m MapSource) Keys() []string {
+	return sortedKeys(m)
+}
+
+// Set sets key in m
+func (m MapSource) Set(key, val string) error {
+	m[key] = val
+	return nil
+}
+
+// Explain returns key's value in m as coming from "map"
+func (m MapSource) Explain(key string) []Origin {
+	return lookupOrigins(m, key, "map")
+}
+
+// lookupOrigins returns key's value in src, if set, as coming from name
+func lookupOrigins(src Source, key, name string) []Origin {
+	origins := make([]Origin, 0, 1)
+	if val, ok := src.Lookup(key); ok {
+		origins = append(origins, Origin{Key: key, Value: val, Source: name})
+	}
+
+	return origins
+}
+
+// DotenvFileSource is a read-only Source holding the values of one or more
+// dotenv files, as read by Overload, without touching the process
+// environment
+type DotenvFileSource struct {
+	files  []dotenvFile
+	values map[string]string
+}
+
+// dotenvFile holds the entries read from one file
+type dotenvFile struct {
+	name    string
+	entries map[string]dotenvEntry
+}
+
+// NewDotenvFileSource reads filenames, defaulting to .env, with later files
+// overriding earlier ones, honoring Lenient, Warn and DisableExpansion
+func NewDotenvFileSource(filenames ...string) (*DotenvFileSource, error) {
+	if len(filenames) == 0 {
+		filenames = []string{".env"}
+	}
+
+	s := &DotenvFileSource{values: make(map[string]string)}
+	for _, filename := range filenames {
+		entries, err := std().readDotenv(filename, s.lookup, true)
+		if err != nil {
+			return nil, err
+		}
+
+		last := lastEntries(entries)
+		for key, entry := range last {
+			s.values[key] = entry.value
+		}
+		s.files = append(s.files, dotenvFile{name: filename, entries: last})
+	}
+
+	return s, nil
+}
+
+// lookup returns key's value in the files read so far, or the environment,
+// to resolve references
+func (s *DotenvFileSource) lookup(key string) (string, bool) {
+	if val, ok := s.values[key]; ok {
+		return val, true
+	}
+
+	return os.LookupEnv(key)
+}
+
+// Filenames returns the files s was read from
+func (s *DotenvFileSource) Filenames() []string {
+	filenames := make([]string, 0, len(s.files))
+	for _, file := range s.files {
+		filenames = append(filenames, file.name)
+	}
+
+	return filenames
+}
+
+// Lookup returns key's value in the files
+func (s *DotenvFileSource) Lookup(key string) (string, bool) {
+	val, ok := s.values[key]
+	return val, ok
+}
+
+// Keys returns the sorted keys set in the files
+func (s *DotenvFileSource) Keys() []string {
+	return sortedKeys(s.values)
+}
+
+// Explain returns key's value in each file setting it, with its file and
+// line, starting with the last file
+func (s *DotenvFileSource) Explain(key string) []Origin {
+	origins := make([]Origin, 0)
+	for i := len(s.files) - 1; i >= 0; i-- {
+		file := s.files[i]
+		if entry, ok := file.entries[key]; ok {
+			origins = append(origins, Origin{Key: key, Value: entry.value, Source: "dotenv", File: file.name, Line: entry.line})
+		}
+	}
+
+	return origins
+}
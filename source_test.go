@@ -0,0 +1,73 @@
+package env
+
+import (
+	. "github.com/jmervine/env/_fixtures"
+
+	"os"
+	"testing"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+func TestOSSource(T *testing.T) {
+	defer UnsetFixtures()
+	SetFixtures()
+
+	var src OSSource
+
+	val, ok := src.Lookup("F_STRING")
+	Go(T).Assert(ok)
+	Go(T).AssertEqual(val, "string")
+
+	Go(T).AssertNil(src.Set("F_STRING", "set"))
+	Go(T).AssertEqual(os.Getenv("F_STRING"), "set")
+
+	keys := src.Keys()
+	for key := range Fixtures {
+		Go(T).AssertContains(keys, key)
+	}
+	Go(T).AssertDeepEqual(Keys(), keys)
+}
+
+func TestMapSource(T *testing.T) {
+	T.Parallel()
+
+	src := MapSource{"B": "2", "A": "1"}
+	Go(T).AssertDeepEqual(src.Keys(), []string{"A", "B"})
+
+	val, ok := src.Lookup("A")
+	Go(T).Assert(ok)
+	Go(T).AssertEqual(val, "1")
+
+	_, ok = src.Lookup("C")
+	Go(T).Refute(ok)
+
+	Go(T).AssertNil(src.Set("C", "3"))
+	Go(T).AssertEqual(src["C"], "3")
+}
+
+func TestDotenvFileSource(T *testing.T) {
+	T.Parallel()
+
+	src, err := NewDotenvFileSource(env)
+	Go(T).AssertNil(err)
+	Go(T).AssertDeepEqual(src.Filenames(), []string{env})
+	Go(T).AssertContains(src.Keys(), "F_INT")
+
+	e := New(src)
+	Go(T).AssertEqual(e.GetInt("F_INT"), 9)
+	Go(T).AssertEqual(e.GetString("F_STRING"), "sample file")
+	Go(T).AssertDeepEqual(e.Keys(), src.Keys())
+
+	// read-only
+	Go(T).AssertEqual(e.Set("F_INT", 1), ErrReadOnly)
+	Go(T).AssertEqual(e.GetOrSetInt("F_MISSING", 1), 1)
+	Go(T).AssertEqual(e.Overload(env), ErrReadOnly)
+	Go(T).AssertEqual(e.GetInt("F_INT"), 9)
+
+	_, ok := os.LookupEnv("F_INT")
+	Go(T).Refute(ok)
+
+	_, err = NewDotenvFileSource("_fixtures/missing.env")
+	Go(T).RefuteNil(err)
+}
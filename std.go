@@ -0,0 +1,734 @@
+package env
+
+import "time"
+
+// The package-level functions call the matching Env method on a default Env
+// backed by the process environment and configured by the package-level
+// variables, see New.
+
+// Load loads a file containing standard os environment key/value pairs,
+// doesn't override currently set variables, including those set to an empty
+// value
+//
+// Files with malformed lines aren't loaded, instead an Errors listing a
+// *SyntaxError for each is returned, unless Lenient is set.
+//
+// e.g.: .env
+//
+//	PORT=3000
+//	ADDR=0.0.0.0
+//	DEBUG=true
+func Load(filenames ...string) error {
+	return std().Load(filenames...)
+}
+
+// Overload does the same thing as Load, but overrides existing variables
+func Overload(filenames ...string) error {
+	return std().Overload(filenames...)
+}
+
+// Set sets via an interface in the process environment
+func Set(key string, val interface{}) {
+	std().Set(key, val)
+}
+
+// SetMap iterates over a map and sets keys to values
+func SetMap(m map[string]interface{}) {
+	std().SetMap(m)
+}
+
+// Keys returns every key set in the process environment
+func Keys() []string {
+	return std().Keys()
+}
+
+// Get gets a key and returns a string
+func Get(key string) string {
+	return std().Get(key)
+}
+
+// Require gets a key and returns a string or an error if it's set to "",
+// or if it fails any of the passed validation rules
+//
+// e.g.:
+//
+//	level, err := env.Require("LOG_LEVEL", env.OneOf("debug", "info", "warn"))
+func Require(key string, rules ...Rule) (string, error) {
+	return std().Require(key, rules...)
+}
+
+// GetOrSet gets a key and returns a string or set's the default
+func GetOrSet(key string, val interface{}) string {
+	return std().GetOrSet(key, val)
+}
+
+// GetString is an alias to Get
+func GetString(key string) string {
+	return std().GetString(key)
+}
+
+// RequireString is an alias to Require
+func RequireString(key string, rules ...Rule) (string, error) {
+	return std().RequireString(key, rules...)
+}
+
+// GetOrSetString is an alias to GetOrSet, except it only takes a string
+// as default value
+func GetOrSetString(key, val string) string {
+	return std().GetOrSetString(key, val)
+}
+
+// GetBytes gets get and converts value to []byte
+func GetBytes(key string) []byte {
+	return std().GetBytes(key)
+}
+
+// RequireBytes requires key and converts value to []byte
+func RequireBytes(key string, rules ...Rule) ([]byte, error) {
+	return std().RequireBytes(key, rules...)
+}
+
+// GetOrSetBytes gets or sets key and returns value as []byte
+func GetOrSetBytes(key string, val []byte) []byte {
+	return std().GetOrSetBytes(key, val)
+}
+
+// GetDuration gets key and returns value as time.Duration
+func GetDuration(key string) time.Duration {
+	return std().GetDuration(key)
+}
+
+// GetDurationE gets key and returns value as time.Duration, or an error if
+// it isn't a valid duration
+func GetDurationE(key string) (time.Duration, error) {
+	return std().GetDurationE(key)
+}
+
+// RequireDuration requires key and returns value as time.Duration
+func RequireDuration(key string, rules ...Rule) (time.Duration, error) {
+	return std().RequireDuration(key, rules...)
+}
+
+// GetOrSetDuration gets or sets key and returns value as time.Duration
+func GetOrSetDuration(key string, val time.Duration) time.Duration {
+	return std().GetOrSetDuration(key, val)
+}
+
+// GetInt gets a key and returns an int
+func GetInt(key string) int {
+	return std().GetInt(key)
+}
+
+// GetIntE gets a key and returns an int, or an error if it isn't a valid int
+func GetIntE(key string) (int, error) {
+	return std().GetIntE(key)
+}
+
+// GetOrSetInt gets or sets key and returns value as int
+func GetOrSetInt(key string, val int) int {
+	return std().GetOrSetInt(key, val)
+}
+
+// RequireInt requires key and returns value as int
+func RequireInt(key string, rules ...Rule) (int, error) {
+	return std().RequireInt(key, rules...)
+}
+
+// GetInt32 gets a key and returns an int32
+func GetInt32(key string) int32 {
+	return std().GetInt32(key)
+}
+
+// GetInt32E gets a key and returns an int32, or an error if it isn't a
+// valid int32
+func GetInt32E(key string) (int32, error) {
+	return std().GetInt32E(key)
+}
+
+// GetOrSetInt32 gets or sets key and returns value as int32
+func GetOrSetInt32(key string, val int32) int32 {
+	return std().GetOrSetInt32(key, val)
+}
+
+// RequireInt32 requires key and returns value as int32
+func RequireInt32(key string, rules ...Rule) (int32, error) {
+	return std().RequireInt32(key, rules...)
+}
+
+// GetInt64 gets a key and returns an int64
+func GetInt64(key string) int64 {
+	return std().GetInt64(key)
+}
+
+// GetInt64E gets a key and returns an int64, or an error if it isn't a
+// valid int64
+func GetInt64E(key string) (int64, error) {
+	return std().GetInt64E(key)
+}
+
+// GetOrSetInt64 gets or sets key and returns value as int64
+func GetOrSetInt64(key string, val int64) int64 {
+	return std().GetOrSetInt64(key, val)
+}
+
+// RequireInt64 requires key and returns value as int64
+func RequireInt64(key string, rules ...Rule) (int64, error) {
+	return std().RequireInt64(key, rules...)
+}
+
+// GetFloat32 gets a key and returns an float32
+func GetFloat32(key string) float32 {
+	return std().GetFloat32(key)
+}
+
+// GetFloat32E gets a key and returns a float32, or an error if it isn't a
+// valid float32
+func GetFloat32E(key string) (float32, error) {
+	return std().GetFloat32E(key)
+}
+
+// GetOrSetFloat32 gets or sets key and returns value as float32
+func GetOrSetFloat32(key string, val float32) float32 {
+	return std().GetOrSetFloat32(key, val)
+}
+
+// RequireFloat32 requires key and returns value as float32
+func RequireFloat32(key string, rules ...Rule) (float32, error) {
+	return std().RequireFloat32(key, rules...)
+}
+
+// GetFloat64 gets a key and returns an float64
+func GetFloat64(key string) float64 {
+	return std().GetFloat64(key)
+}
+
+// GetFloat64E gets a key and returns a float64, or an error if it isn't a
+// valid float64
+func GetFloat64E(key string) (float64, error) {
+	return std().GetFloat64E(key)
+}
+
+// GetOrSetFloat64 gets or sets key and returns value as float64
+func GetOrSetFloat64(key string, val float64) float64 {
+	return std().GetOrSetFloat64(key, val)
+}
+
+// RequireFloat64 requires key and returns value as float64
+func RequireFloat64(key string, rules ...Rule) (float64, error) {
+	return std().RequireFloat64(key, rules...)
+}
+
+// GetBool gets a key and sets to true, false or nil using the Truthy and Falsey
+// variables
+func GetBool(key string) bool {
+	return std().GetBool(key)
+}
+
+// GetBoolE gets a key and returns a bool, or an error if it isn't a valid
+// bool
+func GetBoolE(key string) (bool, error) {
+	return std().GetBoolE(key)
+}
+
+// GetOrSetBool gets or sets key and returns value as bool
+func GetOrSetBool(key string, val bool) bool {
+	return std().GetOrSetBool(key, val)
+}
+
+// RequireBool requires key and returns value as bool
+func RequireBool(key string, rules ...Rule) (bool, error) {
+	return std().RequireBool(key, rules...)
+}
+
+// Lookup gets a key and returns its value and whether it's set, even if
+// it's set to an empty value
+func Lookup(key string) (string, bool) {
+	return std().Lookup(key)
+}
+
+// LookupBytes looks up key and returns value as []byte
+func LookupBytes(key string) ([]byte, bool) {
+	return std().LookupBytes(key)
+}
+
+// LookupDuration looks up key and returns value as time.Duration
+func LookupDuration(key string) (time.Duration, bool) {
+	return std().LookupDuration(key)
+}
+
+// LookupInt looks up key and returns value as int
+func LookupInt(key string) (int, bool) {
+	return std().LookupInt(key)
+}
+
+// LookupInt32 looks up key and returns value as int32
+func LookupInt32(key string) (int32, bool) {
+	return std().LookupInt32(key)
+}
+
+// LookupInt64 looks up key and returns value as int64
+func LookupInt64(key string) (int64, bool) {
+	return std().LookupInt64(key)
+}
+
+// LookupFloat32 looks up key and returns value as float32
+func LookupFloat32(key string) (float32, bool) {
+	return std().LookupFloat32(key)
+}
+
+// LookupFloat64 looks up key and returns value as float64
+func LookupFloat64(key string) (float64, bool) {
+	return std().LookupFloat64(key)
+}
+
+// LookupBool looks up key and returns value as bool
+func LookupBool(key string) (bool, bool) {
+	return std().LookupBool(key)
+}
+
+// IsSet reports whether key counts as set, honoring AllowEmpty, i.e. whether
+// GetOr- and GetOrSet- methods return its value rather than the default
+func IsSet(key string) bool {
+	return std().IsSet(key)
+}
+
+// GetOr gets a key and returns a string or the default, without setting it
+func GetOr(key string, val interface{}) string {
+	return std().GetOr(key, val)
+}
+
+// GetOrString is an alias to GetOr, except it only takes a string as
+// default value
+func GetOrString(key, val string) string {
+	return std().GetOrString(key, val)
+}
+
+// GetOrBytes gets key and returns value as []byte or the default
+func GetOrBytes(key string, val []byte) []byte {
+	return std().GetOrBytes(key, val)
+}
+
+// GetOrDuration gets key and returns value as time.Duration or the default
+func GetOrDuration(key string, val time.Duration) time.Duration {
+	return std().GetOrDuration(key, val)
+}
+
+// GetOrInt gets key and returns value as int or the default
+func GetOrInt(key string, val int) int {
+	return std().GetOrInt(key, val)
+}
+
+// GetOrInt32 gets key and returns value as int32 or the default
+func GetOrInt32(key string, val int32) int32 {
+	return std().GetOrInt32(key, val)
+}
+
+// GetOrInt64 gets key and returns value as int64 or the default
+func GetOrInt64(key string, val int64) int64 {
+	return std().GetOrInt64(key, val)
+}
+
+// GetOrFloat32 gets key and returns value as float32 or the default
+func GetOrFloat32(key string, val float32) float32 {
+	return std().GetOrFloat32(key, val)
+}
+
+// GetOrFloat64 gets key and returns value as float64 or the default
+func GetOrFloat64(key string, val float64) float64 {
+	return std().GetOrFloat64(key, val)
+}
+
+// GetOrBool gets key and returns value as bool or the default
+func GetOrBool(key string, val bool) bool {
+	return std().GetOrBool(key, val)
+}
+
+// GetStrings gets a key and splits it on ListSeparator, trimming whitespace
+// around each element and dropping empty elements. Elements containing the
+// separator can be wrapped in double quotes
+//
+// e.g.:
+//
+//	HOSTS=a.example.com, b.example.com, "c,d"
+func GetStrings(key string) []string {
+	return std().GetStrings(key)
+}
+
+// RequireStrings requires key and returns it as a []string, validating each
+// element against rules
+func RequireStrings(key string, rules ...Rule) ([]string, error) {
+	return std().RequireStrings(key, rules...)
+}
+
+// GetOrSetStrings gets or sets key and returns value as []string
+func GetOrSetStrings(key string, val []string) []string {
+	return std().GetOrSetStrings(key, val)
+}
+
+// GetOrStrings gets key and returns value as []string or the default,
+// without setting it
+func GetOrStrings(key string, val []string) []string {
+	return std().GetOrStrings(key, val)
+}
+
+// GetStringSet gets a key and returns its elements as a set
+func GetStringSet(key string) map[string]bool {
+	return std().GetStringSet(key)
+}
+
+// GetUniqueStrings gets a key and returns its elements with duplicates
+// removed, preserving their order
+func GetUniqueStrings(key string) []string {
+	return std().GetUniqueStrings(key)
+}
+
+// GetInts gets a key and returns it as an []int
+func GetInts(key string) []int {
+	return std().GetInts(key)
+}
+
+// RequireInts requires key and returns it as an []int
+func RequireInts(key string, rules ...Rule) ([]int, error) {
+	return std().RequireInts(key, rules...)
+}
+
+// GetOrSetInts gets or sets key and returns value as []int
+func GetOrSetInts(key string, val []int) []int {
+	return std().GetOrSetInts(key, val)
+}
+
+// GetOrInts gets key and returns value as []int or the default
+func GetOrInts(key string, val []int) []int {
+	return std().GetOrInts(key, val)
+}
+
+// GetFloats gets a key and returns it as a []float64
+func GetFloats(key string) []float64 {
+	return std().GetFloats(key)
+}
+
+// RequireFloats requires key and returns it as a []float64
+func RequireFloats(key string, rules ...Rule) ([]float64, error) {
+	return std().RequireFloats(key, rules...)
+}
+
+// GetOrSetFloats gets or sets key and returns value as []float64
+func GetOrSetFloats(key string, val []float64) []float64 {
+	return std().GetOrSetFloats(key, val)
+}
+
+// GetOrFloats gets key and returns value as []float64 or the default
+func GetOrFloats(key string, val []float64) []float64 {
+	return std().GetOrFloats(key, val)
+}
+
+// GetDurations gets a key and returns it as a []time.Duration
+func GetDurations(key string) []time.Duration {
+	return std().GetDurations(key)
+}
+
+// RequireDurations requires key and returns it as a []time.Duration
+func RequireDurations(key string, rules ...Rule) ([]time.Duration, error) {
+	return std().RequireDurations(key, rules...)
+}
+
+// GetOrSetDurations gets or sets key and returns value as []time.Duration
+func GetOrSetDurations(key string, val []time.Duration) []time.Duration {
+	return std().GetOrSetDurations(key, val)
+}
+
+// GetOrDurations gets key and returns value as []time.Duration or the default
+func GetOrDurations(key string, val []time.Duration) []time.Duration {
+	return std().GetOrDurations(key, val)
+}
+
+// GetBools gets a key and returns it as a []bool
+func GetBools(key string) []bool {
+	return std().GetBools(key)
+}
+
+// RequireBools requires key and returns it as a []bool
+func RequireBools(key string, rules ...Rule) ([]bool, error) {
+	return std().RequireBools(key, rules...)
+}
+
+// GetOrSetBools gets or sets key and returns value as []bool
+func GetOrSetBools(key string, val []bool) []bool {
+	return std().GetOrSetBools(key, val)
+}
+
+// GetOrBools gets key and returns value as []bool or the default
+func GetOrBools(key string, val []bool) []bool {
+	return std().GetOrBools(key, val)
+}
+
+// GetMap gets a key and splits it into pairs on PairSeparator, and each pair
+// into a key and value on KeyValueSeparator, trimming whitespace around
+// both. Pairs containing the separator can be wrapped in double quotes
+//
+// e.g.:
+//
+//	EXTRA_HEADERS=X-A=1, X-B=2, "X-C=3,4"
+func GetMap(key string) map[string]string {
+	return std().GetMap(key)
+}
+
+// RequireMap requires key and returns it as a map[string]string, validating
+// each value against rules
+func RequireMap(key string, rules ...Rule) (map[string]string, error) {
+	return std().RequireMap(key, rules...)
+}
+
+// GetOrSetMap gets or sets key and returns value as map[string]string
+func GetOrSetMap(key string, val map[string]string) map[string]string {
+	return std().GetOrSetMap(key, val)
+}
+
+// GetOrMap gets key and returns value as map[string]string or the default,
+// without setting it
+func GetOrMap(key string, val map[string]string) map[string]string {
+	return std().GetOrMap(key, val)
+}
+
+// GetIntMap gets a key and returns it as a map[string]int
+func GetIntMap(key string) map[string]int {
+	return std().GetIntMap(key)
+}
+
+// RequireIntMap requires key and returns it as a map[string]int
+func RequireIntMap(key string, rules ...Rule) (map[string]int, error) {
+	return std().RequireIntMap(key, rules...)
+}
+
+// GetOrSetIntMap gets or sets key and returns value as map[string]int
+func GetOrSetIntMap(key string, val map[string]int) map[string]int {
+	return std().GetOrSetIntMap(key, val)
+}
+
+// GetOrIntMap gets key and returns value as map[string]int or the default
+func GetOrIntMap(key string, val map[string]int) map[string]int {
+	return std().GetOrIntMap(key, val)
+}
+
+// GetFloatMap gets a key and returns it as a map[string]float64
+func GetFloatMap(key string) map[string]float64 {
+	return std().GetFloatMap(key)
+}
+
+// RequireFloatMap requires key and returns it as a map[string]float64
+func RequireFloatMap(key string, rules ...Rule) (map[string]float64, error) {
+	return std().RequireFloatMap(key, rules...)
+}
+
+// GetOrSetFloatMap gets or sets key and returns value as map[string]float64
+func GetOrSetFloatMap(key string, val map[string]float64) map[string]float64 {
+	return std().GetOrSetFloatMap(key, val)
+}
+
+// GetOrFloatMap gets key and returns value as map[string]float64 or the default
+func GetOrFloatMap(key string, val map[string]float64) map[string]float64 {
+	return std().GetOrFloatMap(key, val)
+}
+
+// GetDurationMap gets a key and returns it as a map[string]time.Duration
+func GetDurationMap(key string) map[string]time.Duration {
+	return std().GetDurationMap(key)
+}
+
+// RequireDurationMap requires key and returns it as a
+// map[string]time.Duration
+func RequireDurationMap(key string, rules ...Rule) (map[string]time.Duration, error) {
+	return std().RequireDurationMap(key, rules...)
+}
+
+// GetOrSetDurationMap gets or sets key and returns value as
+// map[string]time.Duration
+func GetOrSetDurationMap(key string, val map[string]time.Duration) map[string]time.Duration {
+	return std().GetOrSetDurationMap(key, val)
+}
+
+// GetOrDurationMap gets key and returns value as map[string]time.Duration
+// or the default
+func GetOrDurationMap(key string, val map[string]time.Duration) map[string]time.Duration {
+	return std().GetOrDurationMap(key, val)
+}
+
+// GetBoolMap gets a key and returns it as a map[string]bool
+func GetBoolMap(key string) map[string]bool {
+	return std().GetBoolMap(key)
+}
+
+// RequireBoolMap requires key and returns it as a map[string]bool
+func RequireBoolMap(key string, rules ...Rule) (map[string]bool, error) {
+	return std().RequireBoolMap(key, rules...)
+}
+
+// GetOrSetBoolMap gets or sets key and returns value as map[string]bool
+func GetOrSetBoolMap(key string, val map[string]bool) map[string]bool {
+	return std().GetOrSetBoolMap(key, val)
+}
+
+// GetOrBoolMap gets key and returns value as map[string]bool or the default
+func GetOrBoolMap(key string, val map[string]bool) map[string]bool {
+	return std().GetOrBoolMap(key, val)
+}
+
+// GetValue gets key and decodes it into the value pointed to by v, leaving v
+// untouched if key isn't set
+func GetValue(key string, v interface{}) error {
+	return std().GetValue(key, v)
+}
+
+// RequireValue requires key and decodes it into the value pointed to by v
+func RequireValue(key string, v interface{}, rules ...Rule) error {
+	return std().RequireValue(key, v, rules...)
+}
+
+// Unmarshal fills the struct pointed to by v from the environment, using
+// struct tags to map fields to keys
+//
+// e.g.:
+//
+//	type Config struct {
+//	    Port  int           `env:"PORT" default:"3000"`
+//	    DBURL string        `env:"DATABASE_URL" required:"true"`
+//	    TTL   time.Duration `env:"CACHE_TTL" default:"5m"`
+//	    Debug bool          // reads DEBUG
+//	    Skip  string        `env:"-"`
+//	}
+//
+// Malformed values are reported as a *ParseError.
+//
+// Fields whose type has a parser registered with RegisterParser, or which
+// implement Decoder or encoding.TextUnmarshaler, are decoded with them.
+//
+// Fields without an `env` tag are read from their name converted to
+// SNAKE_CASE. Missing required fields are reported the same way Require
+// reports them, honoring PanicOnRequire, as are values failing the rules in
+// a `validate:"min=1,max=65535"` tag (see ParseRules).
+//
+// Nested structs are read using their field name as a key prefix, so a
+// field `DB struct{ Host string }` reads DB_HOST. The prefix can be
+// overridden with a `prefix:"DATABASE_"` tag, and embedded structs share
+// their parent's prefix. Pointer-to-struct fields are only allocated when at
+// least one of their keys is set, so optional sections can be detected as
+// nil.
+func Unmarshal(v interface{}) error {
+	return std().Unmarshal(v)
+}
+
+// MustUnmarshal does the same thing as Unmarshal, but panics on error
+func MustUnmarshal(v interface{}) {
+	std().MustUnmarshal(v)
+}
+
+// Marshal walks the struct (or pointer to struct) v and returns its fields as
+// environment key/value pairs, using the same tags and naming as Unmarshal
+// and the same encoding as Set. Fields implementing encoding.TextMarshaler
+// are encoded with it, and nil pointer-to-struct fields are omitted.
+func Marshal(v interface{}) (map[string]string, error) {
+	return std().Marshal(v)
+}
+
+// WriteFile marshals v and writes it to filename in the format read by Load,
+// sorted by key
+func WriteFile(filename string, v interface{}) error {
+	return std().WriteFile(filename, v)
+}
+
+// RequireAll requires every key, returning an Errors listing each missing
+// key rather than stopping at the first. PanicOnRequire panics once, with
+// the collected errors.
+func RequireAll(keys ...string) error {
+	return std().RequireAll(keys...)
+}
+
+// NewChecker returns an empty Checker reading from the process environment
+func NewChecker() *Checker {
+	return std().NewChecker()
+}
+
+// Explain returns every candidate value for key, highest precedence first,
+// so the first is the value returned by Get
+//
+// Values set by Load, Overload and GetOrSet- methods are reported as
+// coming from their file or "default", as long as they haven't been changed
+// since, and defaults passed to GetOr- methods are listed last.
+//
+// e.g.:
+//
+//	for _, o := range env.Explain("PORT") {
+//	    fmt.Println(o)
+//	}
+func Explain(key string) []Origin {
+	return std().Explain(key)
+}
+
+// Provenance returns where key's value comes from, or false if it isn't set
+// and no default has been used for it
+func Provenance(key string) (Origin, bool) {
+	return std().Provenance(key)
+}
+
+// WithPrefix returns a view of the process environment where every key is
+// read and written with prefix added, e.g.:
+//
+//	payments := env.WithPrefix("PAYMENTS_")
+//	host := payments.Get("HOST") // reads PAYMENTS_HOST
+//
+// Keys lists only the keys starting with prefix, with it stripped, while
+// errors and Explain report fully qualified keys. The view uses the
+// package-level settings as they are when it's created, and prefixes nest,
+// so WithPrefix("A_").WithPrefix("B_") reads A_B_ keys.
+func WithPrefix(prefix string) *Env {
+	return std().WithPrefix(prefix)
+}
+
+// Snapshot returns a copy of every key and value in the process environment
+//
+// e.g.:
+//
+//	before := env.Snapshot()
+//	env.Overload(".env")
+//	log.Print(env.Diff(before, env.Snapshot()))
+func Snapshot() State {
+	return std().Snapshot()
+}
+
+// LoadCascade loads the dotenv files for the current environment, named by
+// APP_ENV, in order of precedence:
+//
+//	.env.$APP_ENV.local
+//	.env.local
+//	.env.$APP_ENV
+//	.env
+//
+// So values in the first files win, and variables which are already set win
+// over all of them, unless opts.Overload is set. Missing files are skipped,
+// as are the .local files in the test environment, which are meant for
+// overrides on a developer's machine. The files which were loaded are
+// returned, highest precedence first.
+//
+// e.g.:
+//
+//	loaded, err := env.LoadCascade(env.CascadeOptions{Dir: "config"})
+func LoadCascade(opts CascadeOptions) ([]string, error) {
+	return std().LoadCascade(opts)
+}
+
+// LoadNearest loads name from the working directory, or the nearest of its
+// parents, as found by FindNearest, returning the path which was loaded.
+// This lets tests and tools run from any package in a project.
+//
+// e.g.:
+//
+//	path, err := env.LoadNearest(".env")
+func LoadNearest(name string) (string, error) {
+	return std().LoadNearest(name)
+}
+
+// LoadNearestFrom does the same thing as LoadNearest, but starts searching
+// from dir
+func LoadNearestFrom(dir, name string) (string, error) {
+	return std().LoadNearestFrom(dir, name)
+}
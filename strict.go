@@ -0,0 +1,92 @@
+package env
+
+import (
+	"time"
+)
+
+// Strict forces getters which can't return an error, such as GetInt or
+// GetOrSetInt, to panic with a *ParseError when a value is malformed,
+// rather than silently returning the zero value. Functions which return an
+// error, such as GetIntE, the Require- methods and Unmarshal, always report
+// malformed values. Keys set to an empty value are never malformed, they're
+// read as the zero value.
+var Strict = false
+
+func (e *Env) strict(err error) {
+	if e.Strict && err != nil {
+		panic(err)
+	}
+}
+
+// the parse- helpers wrap the to- helpers' errors in a *ParseError, reading
+// empty values as the zero value
+
+func parseError(key, typ, val string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ParseError{Key: key, Type: typ, Value: val, Err: err}
+}
+
+func parseBool(key, val string) (bool, error) {
+	if val == "" {
+		return false, nil
+	}
+
+	b, err := toBool(val)
+	return b, parseError(key, "bool", val, err)
+}
+
+func parseDur(key, val string) (time.Duration, error) {
+	if val == "" {
+		return 0, nil
+	}
+
+	d, err := toDur(val)
+	return d, parseError(key, "duration", val, err)
+}
+
+func parseInt(key, val string) (int, error) {
+	if val == "" {
+		return 0, nil
+	}
+
+	i, err := toInt(val)
+	return i, parseError(key, "int", val, err)
+}
+
+func parseInt32(key, val string) (int32, error) {
+	if val == "" {
+		return 0, nil
+	}
+
+	i, err := toInt32(val)
+	return i, parseError(key, "int32", val, err)
+}
+
+func parseInt64(key, val string) (int64, error) {
+	if val == "" {
+		return 0, nil
+	}
+
+	i, err := toInt64(val)
+	return i, parseError(key, "int64", val, err)
+}
+
+func parseFloat32(key, val string) (float32, error) {
+	if val == "" {
+		return 0, nil
+	}
+
+	f, err := toFloat32(val)
+	return f, parseError(key, "float32", val, err)
+}
+
+func parseFloat64(key, val string) (float64, error) {
+	if val == "" {
+		return 0, nil
+	}
+
+	f, err := toFloat64(val)
+	return f, parseError(key, "float64", val, err)
+}
@@ -0,0 +1,118 @@
+package env
+
+import (
+	. "github.com/jmervine/env/_fixtures"
+
+	"os"
+	"testing"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+func TestGetIntE(T *testing.T) {
+	defer os.Unsetenv("S_INT")
+
+	i, e := GetIntE("S_INT")
+	Go(T).AssertNil(e)
+	Go(T).AssertEqual(i, 0)
+
+	Set("S_INT", 40000)
+	i, e = GetIntE("S_INT")
+	Go(T).AssertNil(e)
+	Go(T).AssertEqual(i, 40000)
+	Go(T).AssertEqual(GetInt("S_INT"), 40000)
+
+	Set("S_INT", "80a")
+	i, e = GetIntE("S_INT")
+	Go(T).AssertEqual(i, 0)
+	Go(T).AssertEqual(e.Error(), "invalid int from S_INT: \"80a\": strconv.ParseInt: parsing \"80a\": invalid syntax")
+
+	pe := e.(*ParseError)
+	Go(T).AssertEqual(pe.Key, "S_INT")
+	Go(T).AssertEqual(pe.Type, "int")
+	Go(T).AssertEqual(pe.Value, "80a")
+}
+
+func TestGetE(T *testing.T) {
+	defer UnsetFixtures()
+	SetFixtures()
+
+	_, e := GetInt32E("F_INT32")
+	Go(T).AssertNil(e)
+	_, e = GetInt64E("F_INT64")
+	Go(T).AssertNil(e)
+	_, e = GetFloat32E("F_FLOAT32")
+	Go(T).AssertNil(e)
+	_, e = GetFloat64E("F_FLOAT64")
+	Go(T).AssertNil(e)
+	_, e = GetBoolE("F_BOOL")
+	Go(T).AssertNil(e)
+	_, e = GetDurationE("F_DURATION")
+	Go(T).AssertNil(e)
+
+	_, e = GetInt32E("F_STRING")
+	Go(T).RefuteNil(e)
+	_, e = GetInt64E("F_STRING")
+	Go(T).RefuteNil(e)
+	_, e = GetFloat32E("F_STRING")
+	Go(T).RefuteNil(e)
+	_, e = GetFloat64E("F_STRING")
+	Go(T).RefuteNil(e)
+	_, e = GetBoolE("F_STRING")
+	Go(T).RefuteNil(e)
+	_, e = GetDurationE("F_STRING")
+	Go(T).RefuteNil(e)
+}
+
+func TestRequire_parseError(T *testing.T) {
+	defer UnsetFixtures()
+	SetFixtures()
+
+	i, e := RequireInt("F_STRING")
+	Go(T).AssertEqual(i, 0)
+	_, ok := e.(*ParseError)
+	Go(T).Assert(ok)
+
+	_, e = RequireBool("F_STRING")
+	Go(T).RefuteNil(e)
+
+	_, e = RequireInts("F_STRING")
+	Go(T).RefuteNil(e)
+
+	Set("F_STRING", "a=b")
+	_, e = RequireIntMap("F_STRING")
+	Go(T).RefuteNil(e)
+}
+
+func TestStrict(T *testing.T) {
+	defer UnsetFixtures()
+	SetFixtures()
+
+	// lenient by default
+	Go(T).AssertEqual(GetInt("F_STRING"), 0)
+	Go(T).AssertEqual(GetOrSetInt("F_STRING", 1), 0)
+	Go(T).AssertLength(GetInts("F_STRING"), 0)
+
+	Strict = true
+	defer func() {
+		Strict = false
+		Go(T).RefuteNil(recover())
+	}()
+
+	GetInt("F_STRING")
+}
+
+func TestUnmarshal_parseError(T *testing.T) {
+	defer UnsetFixtures()
+	SetFixtures()
+	Set("F_INT", "80a")
+
+	var c fixtureConfig
+	e := Unmarshal(&c)
+	Go(T).RefuteNil(e)
+
+	pe, ok := e.(*ParseError)
+	Go(T).Assert(ok)
+	Go(T).AssertEqual(pe.Key, "F_INT")
+	Go(T).AssertEqual(pe.Type, "int")
+}
@@ -0,0 +1,380 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Unmarshal fills the struct pointed to by v from the environment, using
+// struct tags to map fields to keys
+//
+// e.g.:
+//
+//	type Config struct {
+//	    Port  int           `env:"PORT" default:"3000"`
+//	    DBURL string        `env:"DATABASE_URL" required:"true"`
+//	    TTL   time.Duration `env:"CACHE_TTL" default:"5m"`
+//	    Debug bool          // reads DEBUG
+//	    Skip  string        `env:"-"`
+//	}
+//
+// Malformed values are reported as a *ParseError. Slice and map fields are
+// split as by GetStrings and GetMap, so they read back what Marshal writes.
+//
+// Fields whose type has a parser registered with RegisterParser, or which
+// implement Decoder or encoding.TextUnmarshaler, are decoded with them.
+//
+// Fields without an `env` tag are read from their name converted to
+// SNAKE_CASE. Missing required fields are reported the same way Require
+// reports them, honoring PanicOnRequire, as are values failing the rules in
+// a `validate:"min=1,max=65535"` tag (see ParseRules).
+//
+// Nested structs are read using their field name as a key prefix, so a
+// field `DB struct{ Host string }` reads DB_HOST. The prefix can be
+// overridden with a `prefix:"DATABASE_"` tag, and embedded structs share
+// their parent's prefix, unless they're unexported pointers, which can't be
+// allocated and are skipped. Pointer-to-struct fields are only allocated
+// when at least one of their keys is set, so optional sections can be
+// detected as nil. Types which nest themselves, such as linked list nodes,
+// are rejected with an error, as their keys would never end.
+func (e *Env) Unmarshal(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("env: Unmarshal requires a non-nil pointer to a struct, got %T", v)
+	}
+
+	if err := checkRecursion(rv.Elem().Type(), nil); err != nil {
+		return err
+	}
+
+	return e.unmarshalStruct(rv.Elem(), "")
+}
+
+// MustUnmarshal does the same thing as Unmarshal, but panics on error
+func (e *Env) MustUnmarshal(v interface{}) {
+	if err := e.Unmarshal(v); err != nil {
+		panic(err)
+	}
+}
+
+func (e *Env) unmarshalStruct(rv reflect.Value, prefix string) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		fv := rv.Field(i)
+
+		if nested, ok := nestedPrefix(field, prefix); ok {
+			if field.Type.Kind() == reflect.Ptr {
+				if !e.anySet(structKeys(field.Type.Elem(), nested)) {
+					continue
+				}
+
+				if fv.IsNil() {
+					fv.Set(reflect.New(field.Type.Elem()))
+				}
+				fv = fv.Elem()
+			}
+
+			if err := e.unmarshalStruct(fv, nested); err != nil {
+				return err
+			}
+			continue
+		}
+
+		key, ok := fieldKey(field, prefix)
+		if !ok {
+			continue
+		}
+
+		str, ok := e.present(key, typeName(field.Type))
+		if !ok {
+			str = field.Tag.Get("default")
+			ok = str != ""
+		}
+
+		if !ok {
+			if required, _ := toBool(field.Tag.Get("required")); required {
+				return e.onError(&MissingError{Key: e.name(key), Type: typeName(field.Type)})
+			}
+			continue
+		}
+
+		rules, err := ParseRules(field.Tag.Get("validate"))
+		if err != nil {
+			return fmt.Errorf("env: %s (%s): %v", field.Name, key, err)
+		}
+
+		if err := validate(e.name(key), typeName(field.Type), str, rules); err != nil {
+			return e.onError(err)
+		}
+
+		if err := e.setField(fv, e.name(key), str); err != nil {
+			return e.onParseError(err)
+		}
+	}
+
+	return nil
+}
+
+// fieldKey returns the environment key for a non-struct field, or false if
+// the field should be skipped
+func fieldKey(field reflect.StructField, prefix string) (string, bool) {
+	// skip unexported fields
+	if field.PkgPath != "" {
+		return "", false
+	}
+
+	key := field.Tag.Get("env")
+	if key == "-" {
+		return "", false
+	}
+
+	if key == "" {
+		key = toSnake(field.Name)
+	}
+
+	return prefix + key, true
+}
+
+// nestedPrefix returns the key prefix for struct and pointer-to-struct
+// fields, or false if the field isn't a nested struct
+func nestedPrefix(field reflect.StructField, prefix string) (string, bool) {
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct || isCustom(t) || field.Tag.Get("env") == "-" {
+		return "", false
+	}
+
+	// skip unexported fields, except embedded structs, whose exported fields
+	// are still settable, unlike those of embedded pointers
+	if field.PkgPath != "" && (!field.Anonymous || field.Type.Kind() == reflect.Ptr) {
+		return "", false
+	}
+
+	if field.Anonymous {
+		return prefix, true
+	}
+
+	if p, ok := field.Tag.Lookup("prefix"); ok {
+		return prefix + p, true
+	}
+
+	if key := field.Tag.Get("env"); key != "" {
+		return prefix + key + "_", true
+	}
+
+	return prefix + toSnake(field.Name) + "_", true
+}
+
+// structKeys returns every key a struct of type rt would read
+func structKeys(rt reflect.Type, prefix string) []string {
+	var keys []string
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+
+		if nested, ok := nestedPrefix(field, prefix); ok {
+			t := field.Type
+			if t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			keys = append(keys, structKeys(t, nested)...)
+			continue
+		}
+
+		if key, ok := fieldKey(field, prefix); ok {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// checkRecursion returns an error if a struct of type rt nests itself,
+// e.g. through a `Next *Node` field, as its keys would never end
+func checkRecursion(rt reflect.Type, path []reflect.Type) error {
+	for _, t := range path {
+		if t == rt {
+			return fmt.Errorf("env: recursive type %s", rt)
+		}
+	}
+	path = append(path, rt)
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if _, ok := nestedPrefix(field, ""); !ok {
+			continue
+		}
+
+		t := field.Type
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		if err := checkRecursion(t, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Env) anySet(keys []string) bool {
+	for _, key := range keys {
+		if _, ok := e.present(key, ""); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setField decodes str into fv, returning a *ParseError when str is
+// malformed. Slices and maps are split as by GetStrings and GetMap, with
+// each element decoded in turn.
+func (e *Env) setField(fv reflect.Value, key, str string) error {
+	if ok, err := decodeCustom(fv, str); ok {
+		return parseError(key, typeName(fv.Type()), str, err)
+	}
+
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		return e.setField(fv.Elem(), key, str)
+	}
+
+	if fv.Type() == durationType {
+		d, err := parseDur(key, str)
+		fv.SetInt(int64(d))
+		return err
+	}
+
+	var err error
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(str)
+	case reflect.Bool:
+		var b bool
+		b, err = parseBool(key, str)
+		fv.SetBool(b)
+	case reflect.Int:
+		var i int
+		i, err = parseInt(key, str)
+		fv.SetInt(int64(i))
+	case reflect.Int32:
+		var i int32
+		i, err = parseInt32(key, str)
+		fv.SetInt(int64(i))
+	case reflect.Int64:
+		var i int64
+		i, err = parseInt64(key, str)
+		fv.SetInt(i)
+	case reflect.Float32:
+		var f float32
+		f, err = parseFloat32(key, str)
+		fv.SetFloat(float64(f))
+	case reflect.Float64:
+		var f float64
+		f, err = parseFloat64(key, str)
+		fv.SetFloat(f)
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() == reflect.Uint8 {
+			fv.SetBytes([]byte(str))
+			break
+		}
+
+		strs := splitList(str, e.ListSeparator)
+		sv := reflect.MakeSlice(fv.Type(), len(strs), len(strs))
+		for i, s := range strs {
+			if err := e.setField(sv.Index(i), key, s); err != nil {
+				return err
+			}
+		}
+		fv.Set(sv)
+	case reflect.Map:
+		m := e.splitMap(str)
+		mv := reflect.MakeMapWithSize(fv.Type(), len(m))
+		for _, k := range sortedKeys(m) {
+			kv := reflect.New(fv.Type().Key()).Elem()
+			if err := e.setField(kv, key, k); err != nil {
+				return err
+			}
+
+			vv := reflect.New(fv.Type().Elem()).Elem()
+			if err := e.setField(vv, key, m[k]); err != nil {
+				return err
+			}
+
+			mv.SetMapIndex(kv, vv)
+		}
+		fv.Set(mv)
+	default:
+		return fmt.Errorf("env: unsupported type %s for %s", fv.Type(), key)
+	}
+
+	return err
+}
+
+// onParseError passes malformed values to onError, returning other errors,
+// such as unsupported types, as is
+func (e *Env) onParseError(err error) error {
+	if _, ok := err.(*ParseError); ok {
+		return e.onError(err)
+	}
+	return err
+}
+
+// typeName returns the name used in error messages for t, matching the
+// names used by the Require- methods for builtin types, and using the Go
+// name of types decoded by a Parser, Decoder or encoding.TextUnmarshaler,
+// e.g. net.IP
+func typeName(t reflect.Type) string {
+	if t == durationType {
+		return "duration"
+	}
+
+	if isCustom(t) {
+		return t.String()
+	}
+
+	if t.Kind() == reflect.Ptr {
+		return typeName(t.Elem())
+	}
+
+	if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+		return "bytes"
+	}
+
+	return t.Kind().String()
+}
+
+// toSnake converts a CamelCase field name to SNAKE_CASE, keeping acronyms
+// together, e.g.: CacheTTL => CACHE_TTL, HTTPPort => HTTP_PORT
+func toSnake(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+
+	return b.String()
+}
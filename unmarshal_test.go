@@ -0,0 +1,218 @@
+package env
+
+import (
+	. "github.com/jmervine/env/_fixtures"
+
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+type fixtureConfig struct {
+	String   string        `env:"F_STRING"`
+	Bytes    []byte        `env:"F_BYTES"`
+	Int      int           `env:"F_INT"`
+	Int32    int32         `env:"F_INT32"`
+	Int64    int64         `env:"F_INT64"`
+	Float32  float32       `env:"F_FLOAT32"`
+	Float64  float64       `env:"F_FLOAT64"`
+	Bool     bool          `env:"F_BOOL"`
+	Duration time.Duration `env:"F_DURATION"`
+	Ignored  string        `env:"-"`
+}
+
+func TestUnmarshal(T *testing.T) {
+	defer UnsetFixtures()
+	SetFixtures()
+
+	var c fixtureConfig
+	err := Unmarshal(&c)
+	Go(T).AssertNil(err)
+
+	Go(T).AssertEqual(c.String, Fixtures["F_STRING"])
+	Go(T).AssertEqual(c.Bytes, Fixtures["F_BYTES"])
+	Go(T).AssertEqual(c.Int, Fixtures["F_INT"])
+	Go(T).AssertEqual(c.Int32, Fixtures["F_INT32"])
+	Go(T).AssertEqual(c.Int64, Fixtures["F_INT64"])
+	Go(T).AssertEqual(c.Float32, Fixtures["F_FLOAT32"])
+	Go(T).AssertEqual(c.Float64, Fixtures["F_FLOAT64"])
+	Go(T).AssertEqual(c.Bool, Fixtures["F_BOOL"])
+	Go(T).AssertEqual(c.Duration, Fixtures["F_DURATION"])
+	Go(T).AssertEqual(c.Ignored, "")
+}
+
+func TestUnmarshal_default(T *testing.T) {
+	defer UnsetFixtures()
+
+	var c struct {
+		Port int    `env:"F_INT" default:"3000"`
+		Addr string `env:"F_STRING" default:"0.0.0.0"`
+	}
+
+	err := Unmarshal(&c)
+	Go(T).AssertNil(err)
+	Go(T).AssertEqual(c.Port, 3000)
+	Go(T).AssertEqual(c.Addr, "0.0.0.0")
+
+	// defaults aren't written back to the environment
+	Go(T).AssertEqual(Get("F_INT"), "")
+}
+
+func TestUnmarshal_required(T *testing.T) {
+	defer UnsetFixtures()
+
+	var c struct {
+		Port int `env:"F_INT" required:"true"`
+	}
+
+	err := Unmarshal(&c)
+	Go(T).RefuteNil(err)
+	Go(T).AssertEqual(err.Error(), "missing required int from F_INT")
+
+	SetFixtures()
+
+	err = Unmarshal(&c)
+	Go(T).AssertNil(err)
+	Go(T).AssertEqual(c.Port, Fixtures["F_INT"])
+}
+
+func TestUnmarshal_invalid(T *testing.T) {
+	var c fixtureConfig
+
+	Go(T).RefuteNil(Unmarshal(c))
+	Go(T).RefuteNil(Unmarshal(nil))
+
+	var s string
+	Go(T).RefuteNil(Unmarshal(&s))
+}
+
+func TestUnmarshal_unsupported(T *testing.T) {
+	defer UnsetFixtures()
+	SetFixtures()
+
+	var c struct {
+		Ints chan int `env:"F_INT"`
+	}
+
+	Go(T).RefuteNil(Unmarshal(&c))
+}
+
+func TestUnmarshal_nested(T *testing.T) {
+	defer UnsetFixtures()
+	defer func() {
+		for _, key := range []string{"DB_HOST", "CACHE_TTL", "HTTP_ADDR"} {
+			os.Unsetenv(key)
+		}
+	}()
+
+	type DB struct {
+		Host string
+		Port int `default:"5432"`
+	}
+
+	type Cache struct {
+		TTL time.Duration
+	}
+
+	type HTTP struct {
+		Addr string
+	}
+
+	type Embedded struct {
+		String string `env:"F_STRING"`
+	}
+
+	var c struct {
+		Embedded
+		DB      DB
+		Cache   *Cache
+		HTTP    *HTTP `prefix:"HTTP_"`
+		Missing *DB   `prefix:"MISSING_"`
+	}
+
+	Set("DB_HOST", "localhost")
+	Set("CACHE_TTL", "1m")
+	Set("HTTP_ADDR", ":8080")
+	SetFixtures()
+
+	err := Unmarshal(&c)
+	Go(T).AssertNil(err)
+
+	Go(T).AssertEqual(c.String, Fixtures["F_STRING"])
+	Go(T).AssertEqual(c.DB.Host, "localhost")
+	Go(T).AssertEqual(c.DB.Port, 5432)
+
+	Go(T).RefuteNil(c.Cache)
+	Go(T).AssertEqual(c.Cache.TTL, time.Minute)
+
+	Go(T).RefuteNil(c.HTTP)
+	Go(T).AssertEqual(c.HTTP.Addr, ":8080")
+
+	Go(T).AssertNil(c.Missing)
+}
+
+type embeddedBase struct {
+	String string `env:"F_STRING"`
+}
+
+func TestUnmarshal_unexportedEmbedded(T *testing.T) {
+	defer UnsetFixtures()
+	SetFixtures()
+
+	var c struct {
+		embeddedBase
+	}
+
+	Go(T).AssertNil(Unmarshal(&c))
+	Go(T).AssertEqual(c.String, Fixtures["F_STRING"])
+
+	var p struct {
+		*embeddedBase
+		Int int `env:"F_INT"`
+	}
+
+	Go(T).AssertNil(Unmarshal(&p))
+	Go(T).AssertNil(p.embeddedBase)
+	Go(T).AssertEqual(p.Int, Fixtures["F_INT"])
+}
+
+type recursiveNode struct {
+	Name string
+	Next *recursiveNode
+}
+
+func TestUnmarshal_recursive(T *testing.T) {
+	var n recursiveNode
+
+	err := Unmarshal(&n)
+	Go(T).RefuteNil(err)
+	Go(T).AssertEqual(err.Error(), "env: recursive type env.recursiveNode")
+
+	_, err = Marshal(n)
+	Go(T).RefuteNil(err)
+}
+
+func Test_toSnake(T *testing.T) {
+	Go(T).AssertEqual(toSnake("Port"), "PORT")
+	Go(T).AssertEqual(toSnake("DBHost"), "DB_HOST")
+	Go(T).AssertEqual(toSnake("CacheTTL"), "CACHE_TTL")
+	Go(T).AssertEqual(toSnake("HTTPPort"), "HTTP_PORT")
+	Go(T).AssertEqual(toSnake("Int32Value"), "INT32_VALUE")
+	Go(T).AssertEqual(toSnake("ID"), "ID")
+}
+
+func TestMustUnmarshal(T *testing.T) {
+	defer UnsetFixtures()
+
+	defer func() {
+		Go(T).RefuteNil(recover())
+	}()
+
+	var c struct {
+		Port int `env:"F_INT" required:"true"`
+	}
+
+	MustUnmarshal(&c)
+}
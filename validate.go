@@ -0,0 +1,208 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Rule validates a raw environment value read as typ, such as "int" or
+// "string", returning an error describing the rule when the value doesn't
+// satisfy it
+type Rule func(value, typ string) error
+
+// Min requires numbers to be at least n, durations to be at least n seconds
+// and strings and other values to be at least n characters long, going by
+// the type they're read as
+func Min(n float64) Rule {
+	return minRule(strconv.FormatFloat(n, 'f', -1, 64))
+}
+
+// Max requires numbers to be at most n, durations to be at most n seconds
+// and strings and other values to be at most n characters long, going by the
+// type they're read as
+func Max(n float64) Rule {
+	return maxRule(strconv.FormatFloat(n, 'f', -1, 64))
+}
+
+// AcceptEmpty makes a single Require- call treat key as present when it's
+// set to an empty value, as AllowEmpty does for every call, e.g.:
+//
+//	suffix, err := env.Require("FEATURE_SUFFIX", env.AcceptEmpty)
+func AcceptEmpty(value, typ string) error {
+	return nil
+}
+
+// acceptsEmpty reports whether rules include AcceptEmpty
+func acceptsEmpty(rules []Rule) bool {
+	accept := reflect.ValueOf(AcceptEmpty).Pointer()
+	for _, rule := range rules {
+		if reflect.ValueOf(rule).Pointer() == accept {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OneOf requires values to match one of vals
+func OneOf(vals ...string) Rule {
+	name := "oneof=" + strings.Join(vals, " ")
+
+	return func(value, typ string) error {
+		for _, v := range vals {
+			if value == v {
+				return nil
+			}
+		}
+		return errors.New(name)
+	}
+}
+
+// Match requires values to match the regular expression pattern, panicking if
+// pattern doesn't compile
+func Match(pattern string) Rule {
+	return matchRule(regexp.MustCompile(pattern))
+}
+
+// ParseRules parses a comma separated list of rules in the format used by
+// the `validate` struct tag
+//
+// e.g.:
+//
+//	min=1,max=65535
+//	oneof=debug info warn
+//	regexp=^[a-z]+$
+//
+// As regular expressions may contain commas, regexp must be the last rule.
+func ParseRules(tag string) ([]Rule, error) {
+	var rules []Rule
+
+	for tag != "" {
+		var part string
+		if strings.HasPrefix(tag, "regexp=") {
+			part, tag = tag, ""
+		} else if i := strings.Index(tag, ","); i >= 0 {
+			part, tag = tag[:i], tag[i+1:]
+		} else {
+			part, tag = tag, ""
+		}
+
+		name, arg, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, fmt.Errorf("env: invalid rule %q", part)
+		}
+
+		switch name {
+		case "min", "max":
+			if _, err := strconv.ParseFloat(arg, 64); err != nil {
+				if _, err := time.ParseDuration(arg); err != nil {
+					return nil, fmt.Errorf("env: invalid %s bound %q", name, arg)
+				}
+			}
+
+			if name == "min" {
+				rules = append(rules, minRule(arg))
+			} else {
+				rules = append(rules, maxRule(arg))
+			}
+		case "oneof":
+			rules = append(rules, OneOf(strings.Fields(arg)...))
+		case "regexp":
+			re, err := regexp.Compile(arg)
+			if err != nil {
+				return nil, fmt.Errorf("env: invalid regexp %q: %v", arg, err)
+			}
+			rules = append(rules, matchRule(re))
+		default:
+			return nil, fmt.Errorf("env: unknown rule %q", name)
+		}
+	}
+
+	return rules, nil
+}
+
+func minRule(bound string) Rule {
+	return func(value, typ string) error {
+		if n, ok := compare(value, typ, bound); !ok || n < 0 {
+			return fmt.Errorf("min=%s", bound)
+		}
+		return nil
+	}
+}
+
+func maxRule(bound string) Rule {
+	return func(value, typ string) error {
+		if n, ok := compare(value, typ, bound); !ok || n > 0 {
+			return fmt.Errorf("max=%s", bound)
+		}
+		return nil
+	}
+}
+
+func matchRule(re *regexp.Regexp) Rule {
+	return func(value, typ string) error {
+		if !re.MatchString(value) {
+			return fmt.Errorf("regexp=%s", re)
+		}
+		return nil
+	}
+}
+
+// compare compares value to bound as a number or duration if typ is a
+// numeric or duration type, and by length otherwise, returning -1, 0 or 1,
+// or false if value isn't of type typ
+func compare(value, typ, bound string) (int, bool) {
+	var a, b float64
+
+	switch typ {
+	case "duration", "durations", "duration map":
+		v, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, false
+		}
+
+		a = v.Seconds()
+		if d, err := time.ParseDuration(bound); err == nil {
+			b = d.Seconds()
+		} else {
+			b, _ = strconv.ParseFloat(bound, 64)
+		}
+	case "int", "int8", "int16", "int32", "int64", "ints", "int map",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64", "floats", "float map":
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, false
+		}
+
+		a = v
+		b, _ = strconv.ParseFloat(bound, 64)
+	default:
+		a = float64(utf8.RuneCountInString(value))
+		b, _ = strconv.ParseFloat(bound, 64)
+	}
+
+	switch {
+	case a < b:
+		return -1, true
+	case a > b:
+		return 1, true
+	}
+	return 0, true
+}
+
+func validate(key, typ, val string, rules []Rule) error {
+	for _, rule := range rules {
+		if err := rule(val, typ); err != nil {
+			return &ValidationError{Key: key, Type: typ, Value: val, Err: err}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package env
+
+import (
+	. "github.com/jmervine/env/_fixtures"
+
+	"errors"
+	"os"
+	"testing"
+
+	. "github.com/jmervine/env/Godeps/_workspace/src/github.com/jmervine/GoT"
+)
+
+func TestRequire_rules(T *testing.T) {
+	defer UnsetFixtures()
+	SetFixtures()
+
+	i, e := RequireInt("F_INT", Min(1), Max(65535))
+	Go(T).AssertNil(e)
+	Go(T).AssertEqual(i, Fixtures["F_INT"])
+
+	i, e = RequireInt("F_INT", Min(2))
+	Go(T).AssertEqual(e.Error(), "invalid int from F_INT: \"1\" does not satisfy min=2")
+	Go(T).AssertEqual(i, 0)
+
+	s, e := Require("F_STRING", OneOf("debug", "info"))
+	Go(T).AssertEqual(e.Error(), "invalid string from F_STRING: \"string\" does not satisfy oneof=debug info")
+	Go(T).AssertEqual(s, "")
+
+	s, e = RequireString("F_STRING", Match("^[a-z]+$"), Max(6))
+	Go(T).AssertNil(e)
+	Go(T).AssertEqual(s, "string")
+
+	d, e := RequireDuration("F_DURATION", Max(60))
+	Go(T).AssertEqual(e.Error(), "invalid duration from F_DURATION: \"1h0m0s\" does not satisfy max=60")
+	Go(T).AssertEqual(int64(d), int64(0))
+}
+
+func TestRequire_rulesByType(T *testing.T) {
+	defer os.Unsetenv("F_PASSWORD")
+	Set("F_PASSWORD", "12345")
+
+	// strings are compared by length, however their value looks
+	_, e := Require("F_PASSWORD", Min(8))
+	Go(T).AssertEqual(e.Error(), "invalid string from F_PASSWORD: \"12345\" does not satisfy min=8")
+
+	_, e = RequireInt("F_PASSWORD", Min(8))
+	Go(T).AssertNil(e)
+
+	Set("F_PASSWORD", "80a")
+	_, e = RequireInt("F_PASSWORD", Min(8))
+	Go(T).AssertEqual(e.Error(), "invalid int from F_PASSWORD: \"80a\" does not satisfy min=8")
+
+	var c struct {
+		Password string `env:"F_PASSWORD" validate:"min=8"`
+	}
+
+	Set("F_PASSWORD", "12345")
+	Go(T).RefuteNil(Unmarshal(&c))
+
+	// interface{} isn't numeric, despite starting with "int"
+	var i struct {
+		Password interface{} `env:"F_PASSWORD" validate:"min=1"`
+	}
+
+	err := Unmarshal(&i)
+	var verr *ValidationError
+	Go(T).Refute(errors.As(err, &verr))
+}
+
+func TestRequire_rulesPanic(T *testing.T) {
+	defer UnsetFixtures()
+	SetFixtures()
+
+	PanicOnRequire = true
+	defer func() {
+		PanicOnRequire = false
+		Go(T).RefuteNil(recover())
+	}()
+
+	RequireFloat64("F_FLOAT64", Min(2))
+}
+
+func TestParseRules(T *testing.T) {
+	rules, err := ParseRules("")
+	Go(T).AssertNil(err)
+	Go(T).AssertLength(rules, 0)
+
+	rules, err = ParseRules("min=1,max=65535,oneof=1 80 443")
+	Go(T).AssertNil(err)
+	Go(T).AssertLength(rules, 3)
+	Go(T).AssertNil(validate("PORT", "int", "80", rules))
+	Go(T).RefuteNil(validate("PORT", "int", "8080", rules))
+	Go(T).RefuteNil(validate("PORT", "int", "99999", rules))
+
+	rules, err = ParseRules("min=1s,regexp=^[0-9]{1,3}s$")
+	Go(T).AssertNil(err)
+	Go(T).AssertNil(validate("TTL", "duration", "30s", rules))
+	Go(T).RefuteNil(validate("TTL", "duration", "500ms", rules))
+	Go(T).RefuteNil(validate("TTL", "duration", "1m", rules))
+
+	_, err = ParseRules("min")
+	Go(T).RefuteNil(err)
+
+	_, err = ParseRules("min=one")
+	Go(T).RefuteNil(err)
+
+	_, err = ParseRules("regexp=[")
+	Go(T).RefuteNil(err)
+
+	_, err = ParseRules("unknown=1")
+	Go(T).RefuteNil(err)
+}
+
+func TestUnmarshal_validate(T *testing.T) {
+	defer os.Unsetenv("LOG_LEVEL")
+
+	var c struct {
+		Port     int    `default:"3000" validate:"min=1,max=65535"`
+		LogLevel string `validate:"oneof=debug info warn"`
+	}
+
+	Go(T).AssertNil(Unmarshal(&c))
+	Go(T).AssertEqual(c.Port, 3000)
+
+	Set("LOG_LEVEL", "verbose")
+	err := Unmarshal(&c)
+	Go(T).AssertEqual(err.Error(), "invalid string from LOG_LEVEL: \"verbose\" does not satisfy oneof=debug info warn")
+
+	var bad struct {
+		Port int `default:"3000" validate:"bogus"`
+	}
+	Go(T).RefuteNil(Unmarshal(&bad))
+}